@@ -4,32 +4,66 @@ import (
 	"github.com/MScuti/gojms/pkg/accouts"
 	"github.com/MScuti/gojms/pkg/apiauth"
 	"github.com/MScuti/gojms/pkg/assets"
+	"github.com/MScuti/gojms/pkg/audits"
+	"github.com/MScuti/gojms/pkg/perms"
 	"github.com/MScuti/gojms/pkg/terminal"
 	"github.com/MScuti/gojms/pkg/users"
 )
 
-// The Terminal struct holds the Sessions object for terminal operations.
-// It is used to manage and interact with terminal sessions.
+// The Terminal struct holds the Sessions, Commands, Tasks, Terminals and storages objects for terminal operations.
+// It is used to manage and interact with terminal sessions, their commands, the tasks sent to them,
+// the terminal components serving them and the storages they record to.
 type Terminal struct {
-	Session terminal.Sessions
+	Session        terminal.Sessions
+	Commands       terminal.Commands
+	Tasks          terminal.Tasks
+	Terminals      terminal.Terminals
+	CommandStorage terminal.CommandStorage
+	ReplayStorage  terminal.ReplayStorage
 }
 
-// The Account struct holds the Account object for account operations.
-// It is used to manage and interact with accounts.
+// The Account struct holds the Account, Templates and automation objects for account operations.
+// It is used to manage and interact with accounts, the templates they are created from and the
+// automations rotating, pushing, verifying and discovering them.
 type Account struct {
-	Account accouts.Account
+	Account                 accouts.Account
+	Templates               accouts.Templates
+	ChangeSecretAutomation  accouts.ChangeSecretAutomation
+	PushAccountAutomation   accouts.PushAccountAutomation
+	VerifyAccountAutomation accouts.VerifyAccountAutomation
+	GatherAccountAutomation accouts.GatherAccountAutomation
+	GatheredAccounts        accouts.GatheredAccounts
 }
 
-// The Assets struct holds the Assets object for asset operations.
-// It is used to manage and interact with assets.
+// The Assets struct holds the Assets and Nodes objects for asset operations.
+// It is used to manage and interact with assets and the asset node tree.
 type Assets struct {
 	Assets assets.Assets
+	Nodes  assets.Nodes
 }
 
-// The User struct holds the User object for user operations.
-// It is used to manage and interact with users.
+// The User struct holds the User and Group objects for user operations.
+// It is used to manage and interact with users and user groups.
 type User struct {
-	User users.User
+	User  users.User
+	Group users.Group
+}
+
+// The Audits struct holds the audit log objects.
+// It is used to consume the operation, login, file transfer and password change logs,
+// and to manage the web sessions of the users.
+type Audits struct {
+	OperateLog        audits.OperateLog
+	LoginLog          audits.LoginLog
+	FTPLogs           audits.FTPLogs
+	PasswordChangeLog audits.PasswordChangeLog
+	UserSessions      audits.UserSessions
+}
+
+// The Perms struct holds the AssetPermission object for permission operations.
+// It is used to manage the rules granting users access to assets.
+type Perms struct {
+	AssetPermission perms.AssetPermission
 }
 
 // The JmsClient struct provides a high level interface to manage Terminal, Account and Assets.
@@ -39,6 +73,8 @@ type JmsClient struct {
 	Account  Account
 	Assets   Assets
 	User     User
+	Audits   Audits
+	Perms    Perms
 }
 
 // JmsAKClient is a struct representing a AKClient entity in the program.
@@ -50,6 +86,8 @@ type JmsClient struct {
 //	Account: This property holds the Account struct for account operations.
 //	Assets: This property contains the Assets structure for asset management operations.
 //	User: This property uses the User struct for user management operations.
+//	Audits: This property holds the Audits struct for audit log operations.
+//	Perms: This property holds the Perms struct for asset permission operations.
 //
 // The struct has been developed to enable easy management and interaction with terminals, accounts,
 // assets, and users.
@@ -66,6 +104,8 @@ type JmsAKClient struct {
 	Account  Account
 	Assets   Assets
 	User     User
+	Audits   Audits
+	Perms    Perms
 }
 
 // JmsSdkClient is a struct representing a SdkClient entity in the program.
@@ -77,6 +117,8 @@ type JmsAKClient struct {
 //	Account: This property holds the Account struct for account operations.
 //	Assets: This property contains the Assets structure for asset management operations.
 //	User: This property uses the User struct for user management operations.
+//	Audits: This property holds the Audits struct for audit log operations.
+//	Perms: This property holds the Perms struct for asset permission operations.
 //
 // The struct has been developed to enable ease in managing and interacting with terminals, accounts,
 // assets, and users.
@@ -93,6 +135,8 @@ type JmsSdkClient struct {
 	Account  Account
 	Assets   Assets
 	User     User
+	Audits   Audits
+	Perms    Perms
 }
 
 // NewJmsClient is a factory function that returns a new JmsClient.
@@ -104,19 +148,66 @@ func NewJmsClient(api apiauth.JmsAPIConfig) *JmsClient {
 			Session: terminal.Sessions{
 				API: &api,
 			},
+			Commands: terminal.Commands{
+				API: &api,
+			},
+			Tasks: terminal.Tasks{
+				API: &api,
+			},
+			Terminals: terminal.Terminals{
+				API: &api,
+			},
+			CommandStorage: terminal.CommandStorage{
+				API: &api,
+			},
+			ReplayStorage: terminal.ReplayStorage{
+				API: &api,
+			},
 		},
 		Account: Account{
 			Account: accouts.Account{
 				API: &api,
 			},
+			Templates: accouts.Templates{
+				API: &api,
+			},
+			ChangeSecretAutomation: accouts.ChangeSecretAutomation{
+				API: &api,
+			},
+			PushAccountAutomation: accouts.PushAccountAutomation{
+				API: &api,
+			},
+			VerifyAccountAutomation: accouts.VerifyAccountAutomation{
+				API: &api,
+			},
+			GatherAccountAutomation: accouts.GatherAccountAutomation{
+				API: &api,
+			},
+			GatheredAccounts: accouts.GatheredAccounts{
+				API: &api,
+			},
 		},
 		Assets: Assets{
 			Assets: assets.Assets{
 				API: &api,
 			},
+			Nodes: assets.Nodes{
+				API: &api,
+			},
 		},
 		User: User{
-			User: users.User{API: &api},
+			User:  users.User{API: &api},
+			Group: users.Group{API: &api},
+		},
+		Audits: Audits{
+			OperateLog:        audits.OperateLog{API: &api},
+			LoginLog:          audits.LoginLog{API: &api},
+			FTPLogs:           audits.FTPLogs{API: &api},
+			PasswordChangeLog: audits.PasswordChangeLog{API: &api},
+			UserSessions:      audits.UserSessions{API: &api},
+		},
+		Perms: Perms{
+			AssetPermission: perms.AssetPermission{API: &api},
 		},
 	}
 }
@@ -128,19 +219,66 @@ func NewJmsAKClient(api apiauth.JmsAKConfig) *JmsAKClient {
 			Session: terminal.Sessions{
 				API: &api,
 			},
+			Commands: terminal.Commands{
+				API: &api,
+			},
+			Tasks: terminal.Tasks{
+				API: &api,
+			},
+			Terminals: terminal.Terminals{
+				API: &api,
+			},
+			CommandStorage: terminal.CommandStorage{
+				API: &api,
+			},
+			ReplayStorage: terminal.ReplayStorage{
+				API: &api,
+			},
 		},
 		Account: Account{
 			Account: accouts.Account{
 				API: &api,
 			},
+			Templates: accouts.Templates{
+				API: &api,
+			},
+			ChangeSecretAutomation: accouts.ChangeSecretAutomation{
+				API: &api,
+			},
+			PushAccountAutomation: accouts.PushAccountAutomation{
+				API: &api,
+			},
+			VerifyAccountAutomation: accouts.VerifyAccountAutomation{
+				API: &api,
+			},
+			GatherAccountAutomation: accouts.GatherAccountAutomation{
+				API: &api,
+			},
+			GatheredAccounts: accouts.GatheredAccounts{
+				API: &api,
+			},
 		},
 		Assets: Assets{
 			Assets: assets.Assets{
 				API: &api,
 			},
+			Nodes: assets.Nodes{
+				API: &api,
+			},
 		},
 		User: User{
-			User: users.User{API: &api},
+			User:  users.User{API: &api},
+			Group: users.Group{API: &api},
+		},
+		Audits: Audits{
+			OperateLog:        audits.OperateLog{API: &api},
+			LoginLog:          audits.LoginLog{API: &api},
+			FTPLogs:           audits.FTPLogs{API: &api},
+			PasswordChangeLog: audits.PasswordChangeLog{API: &api},
+			UserSessions:      audits.UserSessions{API: &api},
+		},
+		Perms: Perms{
+			AssetPermission: perms.AssetPermission{API: &api},
 		},
 	}
 }
@@ -166,19 +304,66 @@ func NewJmsSdkClient(api apiauth.JmsSDKConfig) *JmsSdkClient {
 			Session: terminal.Sessions{
 				API: &api,
 			},
+			Commands: terminal.Commands{
+				API: &api,
+			},
+			Tasks: terminal.Tasks{
+				API: &api,
+			},
+			Terminals: terminal.Terminals{
+				API: &api,
+			},
+			CommandStorage: terminal.CommandStorage{
+				API: &api,
+			},
+			ReplayStorage: terminal.ReplayStorage{
+				API: &api,
+			},
 		},
 		Account: Account{
 			Account: accouts.Account{
 				API: &api,
 			},
+			Templates: accouts.Templates{
+				API: &api,
+			},
+			ChangeSecretAutomation: accouts.ChangeSecretAutomation{
+				API: &api,
+			},
+			PushAccountAutomation: accouts.PushAccountAutomation{
+				API: &api,
+			},
+			VerifyAccountAutomation: accouts.VerifyAccountAutomation{
+				API: &api,
+			},
+			GatherAccountAutomation: accouts.GatherAccountAutomation{
+				API: &api,
+			},
+			GatheredAccounts: accouts.GatheredAccounts{
+				API: &api,
+			},
 		},
 		Assets: Assets{
 			Assets: assets.Assets{
 				API: &api,
 			},
+			Nodes: assets.Nodes{
+				API: &api,
+			},
 		},
 		User: User{
-			User: users.User{API: &api},
+			User:  users.User{API: &api},
+			Group: users.Group{API: &api},
+		},
+		Audits: Audits{
+			OperateLog:        audits.OperateLog{API: &api},
+			LoginLog:          audits.LoginLog{API: &api},
+			FTPLogs:           audits.FTPLogs{API: &api},
+			PasswordChangeLog: audits.PasswordChangeLog{API: &api},
+			UserSessions:      audits.UserSessions{API: &api},
+		},
+		Perms: Perms{
+			AssetPermission: perms.AssetPermission{API: &api},
 		},
 	}
 }
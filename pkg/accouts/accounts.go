@@ -1,6 +1,7 @@
 package accouts
 
 import (
+	"context"
 	"fmt"
 	"github.com/MScuti/gojms/pkg/apiauth"
 	"github.com/MScuti/gojms/pkg/utils"
@@ -15,70 +16,98 @@ type Account struct {
 	API apiauth.JmsAPI
 }
 
-// AccountDetailRep serves as a representation for account details.
-// It features an extensive set of fields correlating to a broad range of account information,
-// such as user id, username, authentication, roles, and more.
-// Fields are tagged with 'json' specifying the JSON payload equivalent name.
-// Empty field means the account detail is not available / not applicable.
+const (
+	// SecretTypePassword is the secret type of the accounts authenticated by a password.
+	SecretTypePassword = "password"
+	// SecretTypeSSHKey is the secret type of the accounts authenticated by an SSH private key.
+	SecretTypeSSHKey = "ssh_key"
+	// SecretTypeAccessKey is the secret type of the cloud accounts authenticated by an access key.
+	SecretTypeAccessKey = "access_key"
+	// SecretTypeToken is the secret type of the accounts authenticated by a token, such as kubernetes accounts.
+	SecretTypeToken = "token"
+	// SecretTypeAPIKey is the secret type of the accounts authenticated by an API key.
+	SecretTypeAPIKey = "api_key"
+)
+
+const (
+	// ConnectivityOK is the connectivity of an account whose secret was verified successfully.
+	ConnectivityOK = "ok"
+	// ConnectivityErr is the connectivity of an account whose secret failed the last verification.
+	ConnectivityErr = "err"
+	// ConnectivityUnknown is the connectivity of an account that was never verified.
+	ConnectivityUnknown = "-"
+)
+
+// Connectivity is the result of the last verification of an account secret.
+// Value is one of the ConnectivityXxx constants.
+type Connectivity struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// IsOK reports whether the last verification of the account secret succeeded.
+func (c Connectivity) IsOK() bool {
+	return c.Value == ConnectivityOK
+}
+
+// IsErr reports whether the last verification of the account secret failed.
+func (c Connectivity) IsErr() bool {
+	return c.Value == ConnectivityErr
+}
+
+// AccountDetailRep represents an account of an asset, the credentials used to log in to it.
+// SecretType is one of the SecretTypeXxx constants, HasSecret tells if a secret is stored, the secret
+// itself is only returned by Account.Secret. SuFrom is the account this one switches from with su,
+// Source tells if the account was created locally, from a template or by the account gathering.
 type AccountDetailRep struct {
 	Id       string `json:"id"`
 	Name     string `json:"name"`
 	Username string `json:"username"`
-	Email    string `json:"email"`
-	Wechat   string `json:"wechat"`
-	Phone    string `json:"phone"`
-	MfaLevel struct {
-		Value int    `json:"value"`
+	Asset    struct {
+		Id       string `json:"id"`
+		Name     string `json:"name"`
+		Address  string `json:"address"`
+		Platform int    `json:"platform_id"`
+		Category struct {
+			Value string `json:"value"`
+			Label string `json:"label"`
+		} `json:"category"`
+		Type struct {
+			Value string `json:"value"`
+			Label string `json:"label"`
+		} `json:"type"`
+	} `json:"asset"`
+	SecretType struct {
+		Value string `json:"value"`
 		Label string `json:"label"`
-	} `json:"mfa_level"`
+	} `json:"secret_type"`
+	HasSecret  bool `json:"has_secret"`
+	Privileged bool `json:"privileged"`
+	IsActive   bool `json:"is_active"`
+	SuFrom     *struct {
+		Id       string `json:"id"`
+		Name     string `json:"name"`
+		Username string `json:"username"`
+	} `json:"su_from"`
 	Source struct {
 		Value string `json:"value"`
 		Label string `json:"label"`
 	} `json:"source"`
-	WecomId     interface{}   `json:"wecom_id"`
-	DingtalkId  interface{}   `json:"dingtalk_id"`
-	FeishuId    interface{}   `json:"feishu_id"`
-	CreatedBy   string        `json:"created_by"`
-	UpdatedBy   string        `json:"updated_by"`
-	Comment     string        `json:"comment"`
-	IsSuperuser bool          `json:"is_superuser"`
-	IsOrgAdmin  bool          `json:"is_org_admin"`
-	AvatarUrl   string        `json:"avatar_url"`
-	Groups      []interface{} `json:"groups"`
-	SystemRoles []struct {
-		Id          string `json:"id"`
-		DisplayName string `json:"display_name"`
-	} `json:"system_roles"`
-	OrgRoles []struct {
-		Id          string `json:"id"`
-		DisplayName string `json:"display_name"`
-		Name        string `json:"name"`
-	} `json:"org_roles"`
-	PasswordStrategy struct {
-		Value string `json:"value"`
-		Label string `json:"label"`
-	} `json:"password_strategy"`
-	IsServiceAccount        bool   `json:"is_service_account"`
-	IsValid                 bool   `json:"is_valid"`
-	IsExpired               bool   `json:"is_expired"`
-	IsActive                bool   `json:"is_active"`
-	IsOtpSecretKeyBound     bool   `json:"is_otp_secret_key_bound"`
-	CanPublicKeyAuth        bool   `json:"can_public_key_auth"`
-	MfaEnabled              bool   `json:"mfa_enabled"`
-	NeedUpdatePassword      bool   `json:"need_update_password"`
-	MfaForceEnabled         bool   `json:"mfa_force_enabled"`
-	IsFirstLogin            bool   `json:"is_first_login"`
-	LoginBlocked            bool   `json:"login_blocked"`
-	DateExpired             string `json:"date_expired"`
-	DateJoined              string `json:"date_joined"`
-	LastLogin               string `json:"last_login"`
-	DateUpdated             string `json:"date_updated"`
-	DatePasswordLastUpdated string `json:"date_password_last_updated"`
-}
-
-// AccountListRep is a type that represents a list of AccountDetailRep objects.
-// It's essentially a slice of AccountDetailRep struct instances representing multiple accounts details.
-type AccountListRep []AccountDetailRep
+	SourceId     string       `json:"source_id"`
+	Connectivity Connectivity `json:"connectivity"`
+	DateVerified string       `json:"date_verified"`
+	Version      int          `json:"version"`
+	Comment      string       `json:"comment"`
+	OrgId        string       `json:"org_id"`
+	OrgName      string       `json:"org_name"`
+	CreatedBy    string       `json:"created_by"`
+	DateCreated  string       `json:"date_created"`
+	DateUpdated  string       `json:"date_updated"`
+}
+
+// AccountListRep is the paginated response of the accounts list endpoint.
+// Results holds the AccountDetailRep of the current page.
+type AccountListRep = apiauth.ListRep[AccountDetailRep]
 
 // Get is a method on the Account struct.
 // It accepts a string id as a parameter and retrieves the account details
@@ -89,9 +118,14 @@ type AccountListRep []AccountDetailRep
 // If the retrieval and unmarshalling are successful, it returns the AccountDetailRep
 // object along with a nil error. Otherwise, it returns nil and the associated error.
 func (a *Account) Get(id string) (*AccountDetailRep, error) {
+	return a.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is the context-aware variant of Get.
+func (a *Account) GetWithContext(ctx context.Context, id string) (*AccountDetailRep, error) {
 	// check id
 	if id == "" {
-		return nil, fmt.Errorf("session id can not empty")
+		return nil, fmt.Errorf("account id can not empty")
 	}
 
 	// combine api endpoint
@@ -99,7 +133,7 @@ func (a *Account) Get(id string) (*AccountDetailRep, error) {
 	endpoint = fmt.Sprintf(endpoint, id)
 
 	// make request
-	req, err := a.API.MakeRequest(http.MethodGet, endpoint, nil)
+	req, err := apiauth.MakeRequestWithContext(ctx, a.API, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -119,26 +153,264 @@ func (a *Account) Get(id string) (*AccountDetailRep, error) {
 // If the operations are successful, it returns a pointer to the AccountListRep object and a nil error.
 // If there's an error during these operations, it returns nil and the error.
 func (a *Account) List(filter *AccountFilter) (*AccountListRep, error) {
+	return a.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is the context-aware variant of List.
+func (a *Account) ListWithContext(ctx context.Context, filter *AccountFilter) (*AccountListRep, error) {
 	// combine api endpoint
 	endpoint := utils.CombineURL(a.API.GetEndpoint(), accountsListAPI)
 
-	// make request
-	req, err := a.API.MakeRequest(http.MethodGet, endpoint, nil)
+	// set query params
+	v, err := query.Values(filter)
 	if err != nil {
 		return nil, err
 	}
 
+	// do request
+	return apiauth.List[AccountDetailRep](ctx, a.API, endpoint, v)
+}
+
+// ListAll fetches every page of the accounts matching filter and returns all of them.
+// The Limit of filter is used as page size, apiauth.DefaultPageSize when it is not set.
+func (a *Account) ListAll(filter *AccountFilter) ([]AccountDetailRep, error) {
+	return a.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (a *Account) ListAllWithContext(ctx context.Context, filter *AccountFilter) ([]AccountDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(a.API.GetEndpoint(), accountsListAPI)
+
 	// set query params
-	if filter != nil {
-		v, err := query.Values(filter)
-		if err != nil {
-			return nil, err
-		}
-		req = a.API.SetQuery(req, v)
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
 	}
 
 	// do request
-	data := &AccountListRep{}
-	err = a.API.DoRequest(req, data)
-	return data, err
+	return apiauth.ListAll[AccountDetailRep](ctx, a.API, endpoint, v)
+}
+
+// Pages returns a pager over the accounts matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (a *Account) Pages(filter *AccountFilter) *apiauth.Pager[AccountDetailRep] {
+	return apiauth.NewQueryPager[AccountDetailRep](a.API, utils.CombineURL(a.API.GetEndpoint(), accountsListAPI), filter)
+}
+
+// Iter returns an iterator over every account matching filter, fetching the pages on demand.
+func (a *Account) Iter(filter *AccountFilter) *apiauth.Iterator[AccountDetailRep] {
+	return apiauth.NewQueryIterator[AccountDetailRep](a.API, utils.CombineURL(a.API.GetEndpoint(), accountsListAPI), filter)
+}
+
+const (
+	// OnInvalidSkip keeps an existing account untouched when bulk adding an account already on an asset.
+	OnInvalidSkip = "skip"
+	// OnInvalidUpdate updates an existing account when bulk adding an account already on an asset.
+	OnInvalidUpdate = "update"
+	// OnInvalidError reports an error for an asset already holding the account when bulk adding it.
+	OnInvalidError = "error"
+)
+
+const (
+	// BulkStateCreated is the state of an asset the account was created on.
+	BulkStateCreated = "created"
+	// BulkStateUpdated is the state of an asset whose existing account was updated.
+	BulkStateUpdated = "updated"
+	// BulkStateSkipped is the state of an asset whose existing account was left untouched.
+	BulkStateSkipped = "skipped"
+	// BulkStateError is the state of an asset the account could not be added to.
+	BulkStateError = "error"
+)
+
+// AccountReq is the request body used to create an account on an asset.
+// SecretType is one of the SecretTypeXxx constants and Secret the password, the PEM encoded SSH private
+// key or the token, Passphrase unlocks an encrypted SSH private key. SuFrom is the id of the account
+// of the same asset this one switches from with su. When PushNow is set the account is also created
+// on the asset itself. IsActive is left to the server default, active, when nil.
+type AccountReq struct {
+	Asset      string  `json:"asset"`
+	Name       string  `json:"name"`
+	Username   string  `json:"username"`
+	SecretType string  `json:"secret_type"`
+	Secret     *Secret `json:"secret,omitempty"`
+	Passphrase *Secret `json:"passphrase,omitempty"`
+	Privileged bool    `json:"privileged"`
+	IsActive   *bool   `json:"is_active,omitempty"`
+	SuFrom     string  `json:"su_from,omitempty"`
+	PushNow    bool    `json:"push_now"`
+	Comment    string  `json:"comment,omitempty"`
+}
+
+// AccountPatchReq is the request body used to partially update an account, only the non nil fields are sent.
+// Secret must be sent together with SecretType.
+type AccountPatchReq struct {
+	Name       *string `json:"name,omitempty"`
+	Username   *string `json:"username,omitempty"`
+	SecretType *string `json:"secret_type,omitempty"`
+	Secret     *Secret `json:"secret,omitempty"`
+	Passphrase *Secret `json:"passphrase,omitempty"`
+	Privileged *bool   `json:"privileged,omitempty"`
+	IsActive   *bool   `json:"is_active,omitempty"`
+	SuFrom     *string `json:"su_from,omitempty"`
+	Comment    *string `json:"comment,omitempty"`
+}
+
+// AccountBulkReq is the request body used to add the same account to many assets at once.
+// The account is added to every asset of Assets and of the nodes of Nodes. OnInvalid is one of the
+// OnInvalidXxx constants and decides what happens on the assets already holding the account.
+// IsActive is left to the server default, active, when nil.
+type AccountBulkReq struct {
+	Assets     []string `json:"assets,omitempty"`
+	Nodes      []string `json:"nodes,omitempty"`
+	Name       string   `json:"name"`
+	Username   string   `json:"username"`
+	SecretType string   `json:"secret_type"`
+	Secret     *Secret  `json:"secret,omitempty"`
+	Passphrase *Secret  `json:"passphrase,omitempty"`
+	Privileged bool     `json:"privileged"`
+	IsActive   *bool    `json:"is_active,omitempty"`
+	OnInvalid  string   `json:"on_invalid"`
+	PushNow    bool     `json:"push_now"`
+	Comment    string   `json:"comment,omitempty"`
+}
+
+// AccountBulkResult is the outcome of a bulk add on an asset.
+// Asset is the asset name followed by its address, State one of the BulkStateXxx constants
+// and Error the reason of a BulkStateError.
+type AccountBulkResult struct {
+	Asset   string `json:"asset"`
+	State   string `json:"state"`
+	Changed bool   `json:"changed"`
+	Error   string `json:"error"`
+}
+
+// Create creates an account on an asset from data and returns it.
+func (a *Account) Create(data *AccountReq) (*AccountDetailRep, error) {
+	return a.CreateWithContext(context.Background(), data)
+}
+
+// CreateWithContext is the context-aware variant of Create.
+func (a *Account) CreateWithContext(ctx context.Context, data *AccountReq) (*AccountDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(a.API.GetEndpoint(), accountsListAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, a.API, http.MethodPost, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	rep := &AccountDetailRep{}
+	err = a.API.DoRequest(req, rep)
+	return rep, err
+}
+
+// Update updates the non nil fields of data on the account identified by id and returns it.
+func (a *Account) Update(id string, data *AccountPatchReq) (*AccountDetailRep, error) {
+	return a.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is the context-aware variant of Update.
+func (a *Account) UpdateWithContext(ctx context.Context, id string, data *AccountPatchReq) (*AccountDetailRep, error) {
+	rep := &AccountDetailRep{}
+	err := a.send(ctx, http.MethodPatch, id, data, rep)
+	return rep, err
+}
+
+// UpdateSecret replaces the secret of the account identified by id with secret of type secretType,
+// one of the SecretTypeXxx constants. Only the secret stored in JumpServer changes, not the one on the asset.
+func (a *Account) UpdateSecret(id, secretType string, secret *Secret) (*AccountDetailRep, error) {
+	return a.UpdateSecretWithContext(context.Background(), id, secretType, secret)
+}
+
+// UpdateSecretWithContext is the context-aware variant of UpdateSecret.
+func (a *Account) UpdateSecretWithContext(ctx context.Context, id, secretType string, secret *Secret) (*AccountDetailRep, error) {
+	return a.UpdateWithContext(ctx, id, &AccountPatchReq{SecretType: &secretType, Secret: secret})
+}
+
+// SetActive enables or disables the account identified by id.
+func (a *Account) SetActive(id string, active bool) (*AccountDetailRep, error) {
+	return a.SetActiveWithContext(context.Background(), id, active)
+}
+
+// SetActiveWithContext is the context-aware variant of SetActive.
+func (a *Account) SetActiveWithContext(ctx context.Context, id string, active bool) (*AccountDetailRep, error) {
+	return a.UpdateWithContext(ctx, id, &AccountPatchReq{IsActive: &active})
+}
+
+// Delete deletes the account identified by id from JumpServer, the account on the asset is kept.
+func (a *Account) Delete(id string) error {
+	return a.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is the context-aware variant of Delete.
+func (a *Account) DeleteWithContext(ctx context.Context, id string) error {
+	return a.send(ctx, http.MethodDelete, id, nil, nil)
+}
+
+// BulkCreate adds the account described by data to every asset it targets and returns the outcome
+// per asset. A failure on an asset does not stop the others, it is reported as a BulkStateError result.
+func (a *Account) BulkCreate(data *AccountBulkReq) ([]AccountBulkResult, error) {
+	return a.BulkCreateWithContext(context.Background(), data)
+}
+
+// BulkCreateWithContext is the context-aware variant of BulkCreate.
+func (a *Account) BulkCreateWithContext(ctx context.Context, data *AccountBulkReq) ([]AccountBulkResult, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(a.API.GetEndpoint(), accountsBulkAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, a.API, http.MethodPost, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	rep := make([]AccountBulkResult, 0)
+	err = a.API.DoRequest(req, &rep)
+	return rep, err
+}
+
+// send sends data with method to the detail endpoint of the account identified by id
+// and decodes the response into result.
+func (a *Account) send(ctx context.Context, method, id string, data, result interface{}) error {
+	// check id
+	if id == "" {
+		return fmt.Errorf("account id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(a.API.GetEndpoint(), fmt.Sprintf(accountsGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, a.API, method, endpoint, data)
+	if err != nil {
+		return err
+	}
+
+	// do request
+	return a.API.DoRequest(req, result)
+}
+
+// Broken fetches every account matching filter whose last verification failed, the accounts whose
+// secret no longer works on their asset. Only the accounts verified at least once can be reported.
+func (a *Account) Broken(filter *AccountFilter) ([]AccountDetailRep, error) {
+	return a.BrokenWithContext(context.Background(), filter)
+}
+
+// BrokenWithContext is the context-aware variant of Broken.
+func (a *Account) BrokenWithContext(ctx context.Context, filter *AccountFilter) ([]AccountDetailRep, error) {
+	accounts, err := a.ListAllWithContext(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	broken := make([]AccountDetailRep, 0)
+	for _, account := range accounts {
+		if account.Connectivity.IsErr() {
+			broken = append(broken, account)
+		}
+	}
+	return broken, nil
 }
@@ -0,0 +1,211 @@
+package accouts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"net/http"
+	"time"
+)
+
+const (
+	// ExecutionStatusPending is the status of an execution waiting for a worker.
+	ExecutionStatusPending = "pending"
+	// ExecutionStatusRunning is the status of an execution in progress.
+	ExecutionStatusRunning = "running"
+	// ExecutionStatusSuccess is the status of an execution that succeeded on every target.
+	ExecutionStatusSuccess = "success"
+	// ExecutionStatusFailed is the status of an execution that failed on some targets.
+	ExecutionStatusFailed = "failed"
+	// ExecutionStatusError is the status of an execution that could not run.
+	ExecutionStatusError = "error"
+)
+
+// defaultPollInterval is the interval between two polls of an execution when none is given.
+const defaultPollInterval = 3 * time.Second
+
+// maxNotFoundPolls is the number of consecutive polls an execution may be not found before giving up.
+const maxNotFoundPolls = 20
+
+// ExecutionStatus is the status of an automation execution. Value is one of the ExecutionStatusXxx constants.
+// The server returns either a plain string or a value and label object depending on its version.
+type ExecutionStatus struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// UnmarshalJSON decodes both the plain string and the value and label object forms.
+func (s *ExecutionStatus) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		s.Label = ""
+		return json.Unmarshal(data, &s.Value)
+	}
+	type status ExecutionStatus
+	return json.Unmarshal(data, (*status)(s))
+}
+
+// ExecutionRep represents an execution of an account automation.
+// Automation is the id of the executed automation and Trigger tells whether it was started
+// manually or by its schedule.
+type ExecutionRep struct {
+	Id         string `json:"id"`
+	Automation string `json:"automation"`
+	Type       string `json:"type"`
+	Trigger    struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"trigger"`
+	Status       ExecutionStatus `json:"status"`
+	DateStart    string          `json:"date_start"`
+	DateFinished string          `json:"date_finished"`
+	Duration     float64         `json:"duration"`
+	OrgId        string          `json:"org_id"`
+}
+
+// IsFinished reports whether the execution is over, whatever its outcome.
+func (e *ExecutionRep) IsFinished() bool {
+	switch e.Status.Value {
+	case "", ExecutionStatusPending, ExecutionStatusRunning:
+		return false
+	}
+	return true
+}
+
+// ExecutionListRep is the paginated response of the automation executions list endpoints.
+type ExecutionListRep = apiauth.ListRep[ExecutionRep]
+
+// Schedule holds the scheduling fields shared by the account automations. A periodic automation
+// runs on Crontab when it is set, every Interval hours otherwise.
+type Schedule struct {
+	IsPeriodic bool   `json:"is_periodic"`
+	Interval   int    `json:"interval,omitempty"`
+	Crontab    string `json:"crontab,omitempty"`
+}
+
+// sendAutomation sends data with method to api, formatted with id unless the request is a POST to a
+// list endpoint, and decodes the response into result.
+func sendAutomation(ctx context.Context, jmsAPI apiauth.JmsAPI, method, api, id string, data, result interface{}) error {
+	// check id
+	if method != http.MethodPost {
+		if id == "" {
+			return fmt.Errorf("automation id can not empty")
+		}
+		api = fmt.Sprintf(api, id)
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(jmsAPI.GetEndpoint(), api)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, jmsAPI, method, endpoint, data)
+	if err != nil {
+		return err
+	}
+
+	// do request
+	return jmsAPI.DoRequest(req, result)
+}
+
+// executeAutomation starts an execution of the automation identified by id through the executions
+// list endpoint api and returns the execution id. The server answers with the id of the task running
+// the execution, which the execution is created with.
+func executeAutomation(ctx context.Context, jmsAPI apiauth.JmsAPI, api, id string) (string, error) {
+	// check id
+	if id == "" {
+		return "", fmt.Errorf("automation id can not empty")
+	}
+
+	// do request
+	rep := &struct {
+		Task string `json:"task"`
+	}{}
+	err := sendAutomation(ctx, jmsAPI, http.MethodPost, api, "", map[string]string{"automation": id}, rep)
+	if err != nil {
+		return "", err
+	}
+	if rep.Task == "" {
+		return "", fmt.Errorf("execute automation %s error, no task returned", id)
+	}
+	return rep.Task, nil
+}
+
+// waitExecution polls the execution identified by id through the execution detail endpoint api every
+// interval until it is finished or ctx is done. The execution is only created once a worker picks the
+// task, so a not found execution is polled again, up to maxNotFoundPolls times in a row before the not
+// found error is returned.
+func waitExecution(ctx context.Context, jmsAPI apiauth.JmsAPI, api, id string, interval time.Duration) (*ExecutionRep, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	notFound := 0
+	for {
+		rep := &ExecutionRep{}
+		err := sendAutomation(ctx, jmsAPI, http.MethodGet, api, id, nil, rep)
+		switch {
+		case err == nil:
+			notFound = 0
+			if rep.IsFinished() {
+				return rep, nil
+			}
+		case apiauth.IsNotFound(err):
+			if notFound++; notFound >= maxNotFoundPolls {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// AccountAutomationRep represents a push account or a verify account automation.
+// Accounts holds the usernames of the accounts handled on every targeted asset, '@ALL' for all of them.
+// Params holds the push parameters per platform type, such as the sudo rules or the shell of the pushed users.
+type AccountAutomationRep struct {
+	Id       string   `json:"id"`
+	Name     string   `json:"name"`
+	Accounts []string `json:"accounts"`
+	Assets   []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"assets"`
+	Nodes []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"nodes"`
+	Params map[string]interface{} `json:"params"`
+	Schedule
+	IsActive       bool   `json:"is_active"`
+	ExecutedAmount int    `json:"executed_amount"`
+	Comment        string `json:"comment"`
+	OrgId          string `json:"org_id"`
+	CreatedBy      string `json:"created_by"`
+	DateCreated    string `json:"date_created"`
+	DateUpdated    string `json:"date_updated"`
+}
+
+// AccountAutomationListRep is the paginated response of the push and verify account automations list endpoints.
+type AccountAutomationListRep = apiauth.ListRep[AccountAutomationRep]
+
+// AccountAutomationReq is the request body used to create or update a push account or a verify account automation.
+// The accounts named by the usernames of Accounts, or '@ALL', are handled on every asset of Assets and of
+// the nodes of Nodes. Params is only used by the push account automations.
+// IsActive is left to the server default, active, when nil.
+type AccountAutomationReq struct {
+	Name     string                 `json:"name"`
+	Accounts []string               `json:"accounts"`
+	Assets   []string               `json:"assets,omitempty"`
+	Nodes    []string               `json:"nodes,omitempty"`
+	Params   map[string]interface{} `json:"params,omitempty"`
+	Schedule
+	IsActive *bool  `json:"is_active,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
@@ -0,0 +1,269 @@
+package accouts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWaitExecution(t *testing.T) {
+	tests := []struct {
+		name     string
+		notFound int
+		running  int
+		status   string
+		wantErr  bool
+		requests int
+	}{
+		{name: "finished", status: `"success"`, requests: 1},
+		{name: "picked up late", notFound: 3, running: 2, status: `{"value":"failed","label":"Failed"}`, requests: 6},
+		{name: "not found under the cap", notFound: maxNotFoundPolls - 1, status: `"success"`, requests: maxNotFoundPolls},
+		{name: "never found", notFound: 1000, wantErr: true, requests: maxNotFoundPolls},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/accounts/push-account-executions/task-1/" {
+					t.Errorf("request sent to %s", r.URL.Path)
+				}
+				switch {
+				case requests <= tt.notFound:
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{"detail":"Not found."}`))
+				case requests <= tt.notFound+tt.running:
+					w.Write([]byte(`{"id":"task-1","status":"running"}`))
+				default:
+					fmt.Fprintf(w, `{"id":"task-1","status":%s}`, tt.status)
+				}
+			}))
+			defer srv.Close()
+
+			api := &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			rep, err := waitExecution(ctx, api, pushAccountExecutionGetAPI, "task-1", time.Millisecond)
+			if tt.wantErr {
+				if !apiauth.IsNotFound(err) {
+					t.Errorf("waitExecution() error = %v, want a not found error", err)
+				}
+			} else if err != nil {
+				t.Errorf("waitExecution() error = %v", err)
+			} else if !rep.IsFinished() {
+				t.Errorf("waitExecution() = %+v, want a finished execution", rep)
+			}
+			if requests != tt.requests {
+				t.Errorf("waitExecution() sent %d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
+
+func TestWaitExecutionContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"task-1","status":"running"}`))
+	}))
+	defer srv.Close()
+
+	api := &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := waitExecution(ctx, api, pushAccountExecutionGetAPI, "task-1", time.Millisecond); err != context.DeadlineExceeded {
+		t.Errorf("waitExecution() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// listServer serves three items on path, paginated with the limit and offset of the query.
+func listServer(t *testing.T, path string) apiauth.JmsAPI {
+	t.Helper()
+	items := []map[string]string{{"id": "1"}, {"id": "2"}, {"id": "3"}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("request sent to %s, want %s", r.URL.Path, path)
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if limit != 2 {
+			t.Errorf("limit = %d, want the limit of the filter", limit)
+		}
+		end := min(offset+limit, len(items))
+		var next interface{}
+		if end < len(items) {
+			next = fmt.Sprintf("%s?limit=%d&offset=%d", path, limit, end)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(items), "next": next, "results": items[offset:end]})
+	}))
+	t.Cleanup(srv.Close)
+	return &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+}
+
+func allLen[T any](items []T, err error) (int, error) {
+	return len(items), err
+}
+
+func pageLen[T any](p *apiauth.Pager[T]) (int, error) {
+	page, err := p.Next(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	return len(page.Results), nil
+}
+
+func iterLen[T any](it *apiauth.Iterator[T]) (int, error) {
+	n := 0
+	for it.Next(context.Background()) {
+		n++
+	}
+	return n, it.Err()
+}
+
+func TestAutomationLists(t *testing.T) {
+	automations, executions := &AutomationFilter{Limit: 2}, &ExecutionFilter{Limit: 2}
+	gathered := &GatheredAccountFilter{Limit: 2}
+	tests := []struct {
+		name  string
+		path  string
+		all   func(api apiauth.JmsAPI) (int, error)
+		pages func(api apiauth.JmsAPI) (int, error)
+		iter  func(api apiauth.JmsAPI) (int, error)
+	}{
+		{
+			name: "change secret automations",
+			path: changeSecretListAPI,
+			all: func(api apiauth.JmsAPI) (int, error) {
+				return allLen((&ChangeSecretAutomation{API: api}).ListAll(automations))
+			},
+			pages: func(api apiauth.JmsAPI) (int, error) {
+				return pageLen((&ChangeSecretAutomation{API: api}).Pages(automations))
+			},
+			iter: func(api apiauth.JmsAPI) (int, error) {
+				return iterLen((&ChangeSecretAutomation{API: api}).Iter(automations))
+			},
+		},
+		{
+			name: "change secret executions",
+			path: changeSecretExecutionListAPI,
+			all: func(api apiauth.JmsAPI) (int, error) {
+				return allLen((&ChangeSecretAutomation{API: api}).ExecutionsAll(executions))
+			},
+			pages: func(api apiauth.JmsAPI) (int, error) {
+				return pageLen((&ChangeSecretAutomation{API: api}).ExecutionPages(executions))
+			},
+			iter: func(api apiauth.JmsAPI) (int, error) {
+				return iterLen((&ChangeSecretAutomation{API: api}).ExecutionIter(executions))
+			},
+		},
+		{
+			name: "push account automations",
+			path: pushAccountListAPI,
+			all: func(api apiauth.JmsAPI) (int, error) {
+				return allLen((&PushAccountAutomation{API: api}).ListAll(automations))
+			},
+			pages: func(api apiauth.JmsAPI) (int, error) {
+				return pageLen((&PushAccountAutomation{API: api}).Pages(automations))
+			},
+			iter: func(api apiauth.JmsAPI) (int, error) {
+				return iterLen((&PushAccountAutomation{API: api}).Iter(automations))
+			},
+		},
+		{
+			name: "push account executions",
+			path: pushAccountExecutionListAPI,
+			all: func(api apiauth.JmsAPI) (int, error) {
+				return allLen((&PushAccountAutomation{API: api}).ExecutionsAll(executions))
+			},
+			pages: func(api apiauth.JmsAPI) (int, error) {
+				return pageLen((&PushAccountAutomation{API: api}).ExecutionPages(executions))
+			},
+			iter: func(api apiauth.JmsAPI) (int, error) {
+				return iterLen((&PushAccountAutomation{API: api}).ExecutionIter(executions))
+			},
+		},
+		{
+			name: "verify account automations",
+			path: verifyAccountListAPI,
+			all: func(api apiauth.JmsAPI) (int, error) {
+				return allLen((&VerifyAccountAutomation{API: api}).ListAll(automations))
+			},
+			pages: func(api apiauth.JmsAPI) (int, error) {
+				return pageLen((&VerifyAccountAutomation{API: api}).Pages(automations))
+			},
+			iter: func(api apiauth.JmsAPI) (int, error) {
+				return iterLen((&VerifyAccountAutomation{API: api}).Iter(automations))
+			},
+		},
+		{
+			name: "verify account executions",
+			path: verifyAccountExecutionListAPI,
+			all: func(api apiauth.JmsAPI) (int, error) {
+				return allLen((&VerifyAccountAutomation{API: api}).ExecutionsAll(executions))
+			},
+			pages: func(api apiauth.JmsAPI) (int, error) {
+				return pageLen((&VerifyAccountAutomation{API: api}).ExecutionPages(executions))
+			},
+			iter: func(api apiauth.JmsAPI) (int, error) {
+				return iterLen((&VerifyAccountAutomation{API: api}).ExecutionIter(executions))
+			},
+		},
+		{
+			name: "gather account automations",
+			path: gatherAccountListAPI,
+			all: func(api apiauth.JmsAPI) (int, error) {
+				return allLen((&GatherAccountAutomation{API: api}).ListAll(automations))
+			},
+			pages: func(api apiauth.JmsAPI) (int, error) {
+				return pageLen((&GatherAccountAutomation{API: api}).Pages(automations))
+			},
+			iter: func(api apiauth.JmsAPI) (int, error) {
+				return iterLen((&GatherAccountAutomation{API: api}).Iter(automations))
+			},
+		},
+		{
+			name: "gather account executions",
+			path: gatherAccountExecutionListAPI,
+			all: func(api apiauth.JmsAPI) (int, error) {
+				return allLen((&GatherAccountAutomation{API: api}).ExecutionsAll(executions))
+			},
+			pages: func(api apiauth.JmsAPI) (int, error) {
+				return pageLen((&GatherAccountAutomation{API: api}).ExecutionPages(executions))
+			},
+			iter: func(api apiauth.JmsAPI) (int, error) {
+				return iterLen((&GatherAccountAutomation{API: api}).ExecutionIter(executions))
+			},
+		},
+		{
+			name: "gathered accounts",
+			path: gatheredAccountListAPI,
+			all: func(api apiauth.JmsAPI) (int, error) {
+				return allLen((&GatheredAccounts{API: api}).ListAll(gathered))
+			},
+			pages: func(api apiauth.JmsAPI) (int, error) {
+				return pageLen((&GatheredAccounts{API: api}).Pages(gathered))
+			},
+			iter: func(api apiauth.JmsAPI) (int, error) {
+				return iterLen((&GatheredAccounts{API: api}).Iter(gathered))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := listServer(t, tt.path)
+			if n, err := tt.all(api); err != nil || n != 3 {
+				t.Errorf("all = %d, %v, want 3 items", n, err)
+			}
+			if n, err := tt.pages(api); err != nil || n != 2 {
+				t.Errorf("first page = %d, %v, want 2 items", n, err)
+			}
+			if n, err := tt.iter(api); err != nil || n != 3 {
+				t.Errorf("iter = %d, %v, want 3 items", n, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package accouts
+
+import (
+	"context"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+	"time"
+)
+
+const (
+	// SSHKeyChangeStrategyAdd adds the new SSH key to the authorized keys of the account.
+	SSHKeyChangeStrategyAdd = "add"
+	// SSHKeyChangeStrategySet replaces every authorized key of the account with the new SSH key.
+	SSHKeyChangeStrategySet = "set"
+	// SSHKeyChangeStrategySetJMS replaces only the SSH key previously set by JumpServer.
+	SSHKeyChangeStrategySetJMS = "set_jms"
+)
+
+// ChangeSecretAutomation is a struct that holds configuration for the JmsAPI.
+// It is used to manage the change secret automations, the plans rotating the account secrets
+// on the assets, their executions and the change records of every account.
+type ChangeSecretAutomation struct {
+	API apiauth.JmsAPI
+}
+
+// ChangeSecretAutomationRep represents a change secret automation.
+// Accounts holds the usernames of the accounts changed on every targeted asset.
+type ChangeSecretAutomationRep struct {
+	Id       string   `json:"id"`
+	Name     string   `json:"name"`
+	Accounts []string `json:"accounts"`
+	Assets   []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"assets"`
+	Nodes []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"nodes"`
+	SecretType struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"secret_type"`
+	SecretStrategy struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"secret_strategy"`
+	PasswordRules        PasswordRules `json:"password_rules"`
+	SSHKeyChangeStrategy struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"ssh_key_change_strategy"`
+	Schedule
+	IsActive       bool   `json:"is_active"`
+	ExecutedAmount int    `json:"executed_amount"`
+	Comment        string `json:"comment"`
+	OrgId          string `json:"org_id"`
+	CreatedBy      string `json:"created_by"`
+	DateCreated    string `json:"date_created"`
+	DateUpdated    string `json:"date_updated"`
+}
+
+// ChangeSecretAutomationListRep is the paginated response of the change secret automations list endpoint.
+type ChangeSecretAutomationListRep = apiauth.ListRep[ChangeSecretAutomationRep]
+
+// ChangeSecretAutomationReq is the request body used to create or update a change secret automation.
+// The accounts named by the usernames of Accounts are changed on every asset of Assets and of the nodes of
+// Nodes. SecretStrategy is one of the SecretStrategyXxx constants: Secret is required by SecretStrategySpecific
+// and PasswordRules used by SecretStrategyRandom. SSHKeyChangeStrategy is one of the SSHKeyChangeStrategyXxx
+// constants. Recipients are the ids of the users receiving the new secrets by mail.
+// IsActive is left to the server default, active, when nil.
+type ChangeSecretAutomationReq struct {
+	Name                 string         `json:"name"`
+	Accounts             []string       `json:"accounts"`
+	Assets               []string       `json:"assets,omitempty"`
+	Nodes                []string       `json:"nodes,omitempty"`
+	SecretType           string         `json:"secret_type"`
+	SecretStrategy       string         `json:"secret_strategy"`
+	Secret               *Secret        `json:"secret,omitempty"`
+	PasswordRules        *PasswordRules `json:"password_rules,omitempty"`
+	SSHKeyChangeStrategy string         `json:"ssh_key_change_strategy,omitempty"`
+	Schedule
+	IsActive   *bool    `json:"is_active,omitempty"`
+	Recipients []string `json:"recipients,omitempty"`
+	Comment    string   `json:"comment,omitempty"`
+}
+
+// ChangeSecretRecordRep is the change of the secret of an account by an execution.
+// Error explains a failed change.
+type ChangeSecretRecordRep struct {
+	Id    string `json:"id"`
+	Asset struct {
+		Id      string `json:"id"`
+		Name    string `json:"name"`
+		Address string `json:"address"`
+	} `json:"asset"`
+	Account struct {
+		Id       string `json:"id"`
+		Name     string `json:"name"`
+		Username string `json:"username"`
+	} `json:"account"`
+	Execution    string          `json:"execution"`
+	IsSuccess    bool            `json:"is_success"`
+	Status       ExecutionStatus `json:"status"`
+	Error        string          `json:"error"`
+	DateStarted  string          `json:"date_started"`
+	DateFinished string          `json:"date_finished"`
+}
+
+// ChangeSecretRecordListRep is the paginated response of the change secret records list endpoint.
+type ChangeSecretRecordListRep = apiauth.ListRep[ChangeSecretRecordRep]
+
+// Get retrieves the change secret automation identified by id.
+func (c *ChangeSecretAutomation) Get(id string) (*ChangeSecretAutomationRep, error) {
+	return c.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is the context-aware variant of Get.
+func (c *ChangeSecretAutomation) GetWithContext(ctx context.Context, id string) (*ChangeSecretAutomationRep, error) {
+	rep := &ChangeSecretAutomationRep{}
+	err := sendAutomation(ctx, c.API, http.MethodGet, changeSecretGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// List retrieves one page of the change secret automations matching filter, or every automation when filter sets no Limit.
+func (c *ChangeSecretAutomation) List(filter *AutomationFilter) (*ChangeSecretAutomationListRep, error) {
+	return c.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is the context-aware variant of List.
+func (c *ChangeSecretAutomation) ListWithContext(ctx context.Context, filter *AutomationFilter) (*ChangeSecretAutomationListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(c.API.GetEndpoint(), changeSecretListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[ChangeSecretAutomationRep](ctx, c.API, endpoint, v)
+}
+
+// ListAll fetches every page of the change secret automations matching filter and returns all of them.
+func (c *ChangeSecretAutomation) ListAll(filter *AutomationFilter) ([]ChangeSecretAutomationRep, error) {
+	return c.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is the context-aware variant of ListAll.
+func (c *ChangeSecretAutomation) ListAllWithContext(ctx context.Context, filter *AutomationFilter) ([]ChangeSecretAutomationRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(c.API.GetEndpoint(), changeSecretListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[ChangeSecretAutomationRep](ctx, c.API, endpoint, v)
+}
+
+// Pages returns a pager over the change secret automations matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (c *ChangeSecretAutomation) Pages(filter *AutomationFilter) *apiauth.Pager[ChangeSecretAutomationRep] {
+	return apiauth.NewQueryPager[ChangeSecretAutomationRep](c.API, utils.CombineURL(c.API.GetEndpoint(), changeSecretListAPI), filter)
+}
+
+// Iter returns an iterator over every change secret automation matching filter, fetching the pages on demand.
+func (c *ChangeSecretAutomation) Iter(filter *AutomationFilter) *apiauth.Iterator[ChangeSecretAutomationRep] {
+	return apiauth.NewQueryIterator[ChangeSecretAutomationRep](c.API, utils.CombineURL(c.API.GetEndpoint(), changeSecretListAPI), filter)
+}
+
+// Create creates a change secret automation from data and returns it.
+func (c *ChangeSecretAutomation) Create(data *ChangeSecretAutomationReq) (*ChangeSecretAutomationRep, error) {
+	return c.CreateWithContext(context.Background(), data)
+}
+
+// CreateWithContext is the context-aware variant of Create.
+func (c *ChangeSecretAutomation) CreateWithContext(ctx context.Context, data *ChangeSecretAutomationReq) (*ChangeSecretAutomationRep, error) {
+	rep := &ChangeSecretAutomationRep{}
+	err := sendAutomation(ctx, c.API, http.MethodPost, changeSecretListAPI, "", data, rep)
+	return rep, err
+}
+
+// Update replaces the change secret automation identified by id with data and returns it.
+func (c *ChangeSecretAutomation) Update(id string, data *ChangeSecretAutomationReq) (*ChangeSecretAutomationRep, error) {
+	return c.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is the context-aware variant of Update.
+func (c *ChangeSecretAutomation) UpdateWithContext(ctx context.Context, id string, data *ChangeSecretAutomationReq) (*ChangeSecretAutomationRep, error) {
+	rep := &ChangeSecretAutomationRep{}
+	err := sendAutomation(ctx, c.API, http.MethodPut, changeSecretGetAPI, id, data, rep)
+	return rep, err
+}
+
+// Delete deletes the change secret automation identified by id together with its executions.
+func (c *ChangeSecretAutomation) Delete(id string) error {
+	return c.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is the context-aware variant of Delete.
+func (c *ChangeSecretAutomation) DeleteWithContext(ctx context.Context, id string) error {
+	return sendAutomation(ctx, c.API, http.MethodDelete, changeSecretGetAPI, id, nil, nil)
+}
+
+// Execute starts an execution of the change secret automation identified by id and returns the execution id,
+// to be passed to Wait or Records.
+func (c *ChangeSecretAutomation) Execute(id string) (string, error) {
+	return c.ExecuteWithContext(context.Background(), id)
+}
+
+// ExecuteWithContext is the context-aware variant of Execute.
+func (c *ChangeSecretAutomation) ExecuteWithContext(ctx context.Context, id string) (string, error) {
+	return executeAutomation(ctx, c.API, changeSecretExecutionListAPI, id)
+}
+
+// Execution retrieves the change secret execution identified by id.
+func (c *ChangeSecretAutomation) Execution(id string) (*ExecutionRep, error) {
+	return c.ExecutionWithContext(context.Background(), id)
+}
+
+// ExecutionWithContext is the context-aware variant of Execution.
+func (c *ChangeSecretAutomation) ExecutionWithContext(ctx context.Context, id string) (*ExecutionRep, error) {
+	rep := &ExecutionRep{}
+	err := sendAutomation(ctx, c.API, http.MethodGet, changeSecretExecutionGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// Executions retrieves one page of the change secret executions matching filter, newest first.
+func (c *ChangeSecretAutomation) Executions(filter *ExecutionFilter) (*ExecutionListRep, error) {
+	return c.ExecutionsWithContext(context.Background(), filter)
+}
+
+// ExecutionsWithContext is the context-aware variant of Executions.
+func (c *ChangeSecretAutomation) ExecutionsWithContext(ctx context.Context, filter *ExecutionFilter) (*ExecutionListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(c.API.GetEndpoint(), changeSecretExecutionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[ExecutionRep](ctx, c.API, endpoint, v)
+}
+
+// ExecutionsAll fetches every page of the change secret executions matching filter, newest first.
+func (c *ChangeSecretAutomation) ExecutionsAll(filter *ExecutionFilter) ([]ExecutionRep, error) {
+	return c.ExecutionsAllWithContext(context.Background(), filter)
+}
+
+// ExecutionsAllWithContext is the context-aware variant of ExecutionsAll.
+func (c *ChangeSecretAutomation) ExecutionsAllWithContext(ctx context.Context, filter *ExecutionFilter) ([]ExecutionRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(c.API.GetEndpoint(), changeSecretExecutionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[ExecutionRep](ctx, c.API, endpoint, v)
+}
+
+// ExecutionPages returns a pager over the change secret executions matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (c *ChangeSecretAutomation) ExecutionPages(filter *ExecutionFilter) *apiauth.Pager[ExecutionRep] {
+	return apiauth.NewQueryPager[ExecutionRep](c.API, utils.CombineURL(c.API.GetEndpoint(), changeSecretExecutionListAPI), filter)
+}
+
+// ExecutionIter returns an iterator over every change secret execution matching filter, fetching the pages on demand.
+func (c *ChangeSecretAutomation) ExecutionIter(filter *ExecutionFilter) *apiauth.Iterator[ExecutionRep] {
+	return apiauth.NewQueryIterator[ExecutionRep](c.API, utils.CombineURL(c.API.GetEndpoint(), changeSecretExecutionListAPI), filter)
+}
+
+// Wait polls the change secret execution identified by id every interval until it is finished and returns it.
+// A zero interval polls every 3 seconds. The execution only exists once a worker picks its task, the not found
+// error is returned when it is still missing after 20 polls, such as for a wrong id.
+func (c *ChangeSecretAutomation) Wait(id string, interval time.Duration) (*ExecutionRep, error) {
+	return c.WaitWithContext(context.Background(), id, interval)
+}
+
+// WaitWithContext is like Wait but gives up with the error of ctx once it is done.
+func (c *ChangeSecretAutomation) WaitWithContext(ctx context.Context, id string, interval time.Duration) (*ExecutionRep, error) {
+	return waitExecution(ctx, c.API, changeSecretExecutionGetAPI, id, interval)
+}
+
+// Records fetches every change secret record matching filter, usually the records of an execution.
+func (c *ChangeSecretAutomation) Records(filter *ChangeSecretRecordFilter) ([]ChangeSecretRecordRep, error) {
+	return c.RecordsWithContext(context.Background(), filter)
+}
+
+// RecordsWithContext is the context-aware variant of Records.
+func (c *ChangeSecretAutomation) RecordsWithContext(ctx context.Context, filter *ChangeSecretRecordFilter) ([]ChangeSecretRecordRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(c.API.GetEndpoint(), changeSecretRecordListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[ChangeSecretRecordRep](ctx, c.API, endpoint, v)
+}
@@ -1,6 +1,30 @@
 package accouts
 
 const (
-	accountsGetAPI  = "/accounts/accounts/%s/"
-	accountsListAPI = "/accounts/accounts/"
+	accountsGetAPI                = "/accounts/accounts/%s/"
+	accountsListAPI               = "/accounts/accounts/"
+	accountsBulkAPI               = "/accounts/accounts/bulk/"
+	accountSecretAPI              = "/accounts/account-secrets/%s/"
+	templateGetAPI                = "/accounts/account-templates/%s/"
+	templatesListAPI              = "/accounts/account-templates/"
+	changeSecretGetAPI            = "/accounts/change-secret-automations/%s/"
+	changeSecretListAPI           = "/accounts/change-secret-automations/"
+	changeSecretExecutionGetAPI   = "/accounts/change-secret-executions/%s/"
+	changeSecretExecutionListAPI  = "/accounts/change-secret-executions/"
+	changeSecretRecordListAPI     = "/accounts/change-secret-records/"
+	pushAccountGetAPI             = "/accounts/push-account-automations/%s/"
+	pushAccountListAPI            = "/accounts/push-account-automations/"
+	pushAccountExecutionGetAPI    = "/accounts/push-account-executions/%s/"
+	pushAccountExecutionListAPI   = "/accounts/push-account-executions/"
+	verifyAccountGetAPI           = "/accounts/verify-account-automations/%s/"
+	verifyAccountListAPI          = "/accounts/verify-account-automations/"
+	verifyAccountExecutionGetAPI  = "/accounts/verify-account-executions/%s/"
+	verifyAccountExecutionListAPI = "/accounts/verify-account-executions/"
+	gatherAccountGetAPI           = "/accounts/gather-account-automations/%s/"
+	gatherAccountListAPI          = "/accounts/gather-account-automations/"
+	gatherAccountExecutionGetAPI  = "/accounts/gather-account-executions/%s/"
+	gatherAccountExecutionListAPI = "/accounts/gather-account-executions/"
+	gatheredAccountGetAPI         = "/accounts/gathered-accounts/%s/"
+	gatheredAccountListAPI        = "/accounts/gathered-accounts/"
+	gatheredAccountSyncAPI        = "/accounts/gathered-accounts/sync-accounts/"
 )
@@ -4,9 +4,9 @@ package accouts
 // Each struct field corresponds to a possible filter, and the `url` tag specifies the URL
 // query string parameter name associated with the struct field. All filters are optional,
 // so empty fields will not be used in the final query.
-// Allowed filters include ID, Asset, SourceID, SecretType, IP, HostName, Address,
-// AssetID, Assets, Nodes, NodeID, HasSecret, Platform, Category, Type, Search, Order,
-// Limit, Offset.
+// Allowed filters include ID, Asset, SourceID, SecretType, IP, HostName, Username, Address,
+// AssetID, Assets, Nodes, NodeID, HasSecret, Privileged, IsActive, Platform, Category, Type,
+// Search, Order, Limit, Offset.
 type AccountFilter struct {
 	ID         string `url:"id"`
 	Asset      string `url:"asset"`
@@ -14,12 +14,15 @@ type AccountFilter struct {
 	SecretType string `url:"secret_type"`
 	IP         string `url:"ip"`
 	HostName   string `url:"hostname"`
+	Username   string `url:"username"`
 	Address    string `url:"address"`
 	AssetID    string `url:"asset_id"`
 	Assets     string `url:"assets"`
 	Nodes      string `url:"nodes"`
 	NodeID     string `url:"node_id"`
 	HasSecret  string `url:"has_secret"`
+	Privileged string `url:"privileged"`
+	IsActive   string `url:"is_active"`
 	Platform   string `url:"platform"`
 	Category   string `url:"category"`
 	Type       string `url:"type"`
@@ -28,3 +31,65 @@ type AccountFilter struct {
 	Limit      int    `url:"limit"`
 	Offset     int    `url:"offset"`
 }
+
+// TemplateFilter represents the filtering options for querying the account templates.
+// Allowed filters include Name, Username, SecretType, Privileged, IsActive, Search, Order, Limit, Offset.
+type TemplateFilter struct {
+	Name       string `url:"name"`
+	Username   string `url:"username"`
+	SecretType string `url:"secret_type"`
+	Privileged string `url:"privileged"`
+	IsActive   string `url:"is_active"`
+	Search     string `url:"search"`
+	Order      string `url:"order"`
+	Limit      int    `url:"limit"`
+	Offset     int    `url:"offset"`
+}
+
+// AutomationFilter represents the filtering options for querying the account automations.
+// Allowed filters include Name, IsActive, IsPeriodic, Search, Order, Limit, Offset.
+type AutomationFilter struct {
+	Name       string `url:"name"`
+	IsActive   string `url:"is_active"`
+	IsPeriodic string `url:"is_periodic"`
+	Search     string `url:"search"`
+	Order      string `url:"order"`
+	Limit      int    `url:"limit"`
+	Offset     int    `url:"offset"`
+}
+
+// ExecutionFilter represents the filtering options for querying the executions of the account automations.
+// AutomationID filters the executions of an automation, Trigger the manual or periodic executions.
+type ExecutionFilter struct {
+	AutomationID string `url:"automation_id"`
+	Trigger      string `url:"trigger"`
+	Search       string `url:"search"`
+	Order        string `url:"order"`
+	Limit        int    `url:"limit"`
+	Offset       int    `url:"offset"`
+}
+
+// ChangeSecretRecordFilter represents the filtering options for querying the change secret records.
+// ExecutionID filters the records of an execution, AssetID the records of an asset.
+type ChangeSecretRecordFilter struct {
+	ExecutionID string `url:"execution_id"`
+	AssetID     string `url:"asset_id"`
+	Search      string `url:"search"`
+	Order       string `url:"order"`
+	Limit       int    `url:"limit"`
+	Offset      int    `url:"offset"`
+}
+
+// GatheredAccountFilter represents the filtering options for querying the gathered accounts.
+// AssetID and NodeID filter the accounts discovered on an asset or under a node, Present filters the
+// accounts still found ('true') or gone ('false') on their asset.
+type GatheredAccountFilter struct {
+	Username string `url:"username"`
+	AssetID  string `url:"asset_id"`
+	NodeID   string `url:"node_id"`
+	Present  string `url:"present"`
+	Search   string `url:"search"`
+	Order    string `url:"order"`
+	Limit    int    `url:"limit"`
+	Offset   int    `url:"offset"`
+}
@@ -0,0 +1,371 @@
+package accouts
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+	"time"
+)
+
+// GatherAccountAutomation is a struct that holds configuration for the JmsAPI.
+// It is used to manage the gather account automations, which discover the local accounts of the assets,
+// and their executions. The discovered accounts are read with GatheredAccounts.
+type GatherAccountAutomation struct {
+	API apiauth.JmsAPI
+}
+
+// GatheredAccounts is a struct that holds configuration for the JmsAPI.
+// It is used to read the accounts discovered on the assets by the gather account automations
+// and to sync them into the managed accounts.
+type GatheredAccounts struct {
+	API apiauth.JmsAPI
+}
+
+// GatherAccountAutomationRep represents a gather account automation.
+// When IsSyncAccount is set the discovered accounts are added to the managed accounts automatically.
+type GatherAccountAutomationRep struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	Assets []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"assets"`
+	Nodes []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"nodes"`
+	IsSyncAccount bool     `json:"is_sync_account"`
+	Recipients    []string `json:"recipients"`
+	Schedule
+	IsActive       bool   `json:"is_active"`
+	ExecutedAmount int    `json:"executed_amount"`
+	Comment        string `json:"comment"`
+	OrgId          string `json:"org_id"`
+	CreatedBy      string `json:"created_by"`
+	DateCreated    string `json:"date_created"`
+	DateUpdated    string `json:"date_updated"`
+}
+
+// GatherAccountAutomationListRep is the paginated response of the gather account automations list endpoint.
+type GatherAccountAutomationListRep = apiauth.ListRep[GatherAccountAutomationRep]
+
+// GatherAccountAutomationReq is the request body used to create or update a gather account automation.
+// The accounts are discovered on every asset of Assets and of the nodes of Nodes. Recipients are the ids
+// of the users receiving the report of every execution. IsActive is left to the server default, active, when nil.
+type GatherAccountAutomationReq struct {
+	Name          string   `json:"name"`
+	Assets        []string `json:"assets,omitempty"`
+	Nodes         []string `json:"nodes,omitempty"`
+	IsSyncAccount bool     `json:"is_sync_account"`
+	Recipients    []string `json:"recipients,omitempty"`
+	Schedule
+	IsActive *bool  `json:"is_active,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// GatheredAccountRep represents an account discovered on an asset.
+// Present is false once the account is no longer found on the asset by a later execution.
+type GatheredAccountRep struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+	Asset    struct {
+		Id      string `json:"id"`
+		Name    string `json:"name"`
+		Address string `json:"address"`
+	} `json:"asset"`
+	Present          bool   `json:"present"`
+	AddressLastLogin string `json:"address_last_login"`
+	DateLastLogin    string `json:"date_last_login"`
+	DateCreated      string `json:"date_created"`
+	DateUpdated      string `json:"date_updated"`
+	OrgId            string `json:"org_id"`
+}
+
+// GatheredAccountListRep is the paginated response of the gathered accounts list endpoint.
+type GatheredAccountListRep = apiauth.ListRep[GatheredAccountRep]
+
+// Get retrieves the gather account automation identified by id.
+func (g *GatherAccountAutomation) Get(id string) (*GatherAccountAutomationRep, error) {
+	return g.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is the context-aware variant of Get.
+func (g *GatherAccountAutomation) GetWithContext(ctx context.Context, id string) (*GatherAccountAutomationRep, error) {
+	rep := &GatherAccountAutomationRep{}
+	err := sendAutomation(ctx, g.API, http.MethodGet, gatherAccountGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// List retrieves one page of the gather account automations matching filter, or every automation when filter sets no Limit.
+func (g *GatherAccountAutomation) List(filter *AutomationFilter) (*GatherAccountAutomationListRep, error) {
+	return g.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is the context-aware variant of List.
+func (g *GatherAccountAutomation) ListWithContext(ctx context.Context, filter *AutomationFilter) (*GatherAccountAutomationListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), gatherAccountListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[GatherAccountAutomationRep](ctx, g.API, endpoint, v)
+}
+
+// ListAll fetches every page of the gather account automations matching filter and returns all of them.
+func (g *GatherAccountAutomation) ListAll(filter *AutomationFilter) ([]GatherAccountAutomationRep, error) {
+	return g.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is the context-aware variant of ListAll.
+func (g *GatherAccountAutomation) ListAllWithContext(ctx context.Context, filter *AutomationFilter) ([]GatherAccountAutomationRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), gatherAccountListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[GatherAccountAutomationRep](ctx, g.API, endpoint, v)
+}
+
+// Pages returns a pager over the gather account automations matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (g *GatherAccountAutomation) Pages(filter *AutomationFilter) *apiauth.Pager[GatherAccountAutomationRep] {
+	return apiauth.NewQueryPager[GatherAccountAutomationRep](g.API, utils.CombineURL(g.API.GetEndpoint(), gatherAccountListAPI), filter)
+}
+
+// Iter returns an iterator over every gather account automation matching filter, fetching the pages on demand.
+func (g *GatherAccountAutomation) Iter(filter *AutomationFilter) *apiauth.Iterator[GatherAccountAutomationRep] {
+	return apiauth.NewQueryIterator[GatherAccountAutomationRep](g.API, utils.CombineURL(g.API.GetEndpoint(), gatherAccountListAPI), filter)
+}
+
+// Create creates a gather account automation from data and returns it.
+func (g *GatherAccountAutomation) Create(data *GatherAccountAutomationReq) (*GatherAccountAutomationRep, error) {
+	return g.CreateWithContext(context.Background(), data)
+}
+
+// CreateWithContext is the context-aware variant of Create.
+func (g *GatherAccountAutomation) CreateWithContext(ctx context.Context, data *GatherAccountAutomationReq) (*GatherAccountAutomationRep, error) {
+	rep := &GatherAccountAutomationRep{}
+	err := sendAutomation(ctx, g.API, http.MethodPost, gatherAccountListAPI, "", data, rep)
+	return rep, err
+}
+
+// Update replaces the gather account automation identified by id with data and returns it.
+func (g *GatherAccountAutomation) Update(id string, data *GatherAccountAutomationReq) (*GatherAccountAutomationRep, error) {
+	return g.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is the context-aware variant of Update.
+func (g *GatherAccountAutomation) UpdateWithContext(ctx context.Context, id string, data *GatherAccountAutomationReq) (*GatherAccountAutomationRep, error) {
+	rep := &GatherAccountAutomationRep{}
+	err := sendAutomation(ctx, g.API, http.MethodPut, gatherAccountGetAPI, id, data, rep)
+	return rep, err
+}
+
+// Delete deletes the gather account automation identified by id together with its executions.
+func (g *GatherAccountAutomation) Delete(id string) error {
+	return g.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is the context-aware variant of Delete.
+func (g *GatherAccountAutomation) DeleteWithContext(ctx context.Context, id string) error {
+	return sendAutomation(ctx, g.API, http.MethodDelete, gatherAccountGetAPI, id, nil, nil)
+}
+
+// Execute starts an execution of the gather account automation identified by id and returns the execution id.
+func (g *GatherAccountAutomation) Execute(id string) (string, error) {
+	return g.ExecuteWithContext(context.Background(), id)
+}
+
+// ExecuteWithContext is the context-aware variant of Execute.
+func (g *GatherAccountAutomation) ExecuteWithContext(ctx context.Context, id string) (string, error) {
+	return executeAutomation(ctx, g.API, gatherAccountExecutionListAPI, id)
+}
+
+// Execution retrieves the gather account execution identified by id.
+func (g *GatherAccountAutomation) Execution(id string) (*ExecutionRep, error) {
+	return g.ExecutionWithContext(context.Background(), id)
+}
+
+// ExecutionWithContext is the context-aware variant of Execution.
+func (g *GatherAccountAutomation) ExecutionWithContext(ctx context.Context, id string) (*ExecutionRep, error) {
+	rep := &ExecutionRep{}
+	err := sendAutomation(ctx, g.API, http.MethodGet, gatherAccountExecutionGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// Executions retrieves one page of the gather account executions matching filter, newest first.
+func (g *GatherAccountAutomation) Executions(filter *ExecutionFilter) (*ExecutionListRep, error) {
+	return g.ExecutionsWithContext(context.Background(), filter)
+}
+
+// ExecutionsWithContext is the context-aware variant of Executions.
+func (g *GatherAccountAutomation) ExecutionsWithContext(ctx context.Context, filter *ExecutionFilter) (*ExecutionListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), gatherAccountExecutionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[ExecutionRep](ctx, g.API, endpoint, v)
+}
+
+// ExecutionsAll fetches every page of the gather account executions matching filter, newest first.
+func (g *GatherAccountAutomation) ExecutionsAll(filter *ExecutionFilter) ([]ExecutionRep, error) {
+	return g.ExecutionsAllWithContext(context.Background(), filter)
+}
+
+// ExecutionsAllWithContext is the context-aware variant of ExecutionsAll.
+func (g *GatherAccountAutomation) ExecutionsAllWithContext(ctx context.Context, filter *ExecutionFilter) ([]ExecutionRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), gatherAccountExecutionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[ExecutionRep](ctx, g.API, endpoint, v)
+}
+
+// ExecutionPages returns a pager over the gather account executions matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (g *GatherAccountAutomation) ExecutionPages(filter *ExecutionFilter) *apiauth.Pager[ExecutionRep] {
+	return apiauth.NewQueryPager[ExecutionRep](g.API, utils.CombineURL(g.API.GetEndpoint(), gatherAccountExecutionListAPI), filter)
+}
+
+// ExecutionIter returns an iterator over every gather account execution matching filter, fetching the pages on demand.
+func (g *GatherAccountAutomation) ExecutionIter(filter *ExecutionFilter) *apiauth.Iterator[ExecutionRep] {
+	return apiauth.NewQueryIterator[ExecutionRep](g.API, utils.CombineURL(g.API.GetEndpoint(), gatherAccountExecutionListAPI), filter)
+}
+
+// Wait polls the gather account execution identified by id every interval until it is finished and returns it.
+// A zero interval polls every 3 seconds. The execution only exists once a worker picks its task, the not found
+// error is returned when it is still missing after 20 polls, such as for a wrong id.
+func (g *GatherAccountAutomation) Wait(id string, interval time.Duration) (*ExecutionRep, error) {
+	return g.WaitWithContext(context.Background(), id, interval)
+}
+
+// WaitWithContext is like Wait but gives up with the error of ctx once it is done.
+func (g *GatherAccountAutomation) WaitWithContext(ctx context.Context, id string, interval time.Duration) (*ExecutionRep, error) {
+	return waitExecution(ctx, g.API, gatherAccountExecutionGetAPI, id, interval)
+}
+
+// Get retrieves the gathered account identified by id.
+func (g *GatheredAccounts) Get(id string) (*GatheredAccountRep, error) {
+	return g.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is the context-aware variant of Get.
+func (g *GatheredAccounts) GetWithContext(ctx context.Context, id string) (*GatheredAccountRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("gathered account id can not empty")
+	}
+	rep := &GatheredAccountRep{}
+	err := sendAutomation(ctx, g.API, http.MethodGet, gatheredAccountGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// List retrieves one page of the gathered accounts matching filter, or every account when filter sets no Limit.
+func (g *GatheredAccounts) List(filter *GatheredAccountFilter) (*GatheredAccountListRep, error) {
+	return g.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is the context-aware variant of List.
+func (g *GatheredAccounts) ListWithContext(ctx context.Context, filter *GatheredAccountFilter) (*GatheredAccountListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), gatheredAccountListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[GatheredAccountRep](ctx, g.API, endpoint, v)
+}
+
+// ListAll fetches every page of the gathered accounts matching filter and returns all of them.
+func (g *GatheredAccounts) ListAll(filter *GatheredAccountFilter) ([]GatheredAccountRep, error) {
+	return g.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is the context-aware variant of ListAll.
+func (g *GatheredAccounts) ListAllWithContext(ctx context.Context, filter *GatheredAccountFilter) ([]GatheredAccountRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), gatheredAccountListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[GatheredAccountRep](ctx, g.API, endpoint, v)
+}
+
+// Pages returns a pager over the gathered accounts matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (g *GatheredAccounts) Pages(filter *GatheredAccountFilter) *apiauth.Pager[GatheredAccountRep] {
+	return apiauth.NewQueryPager[GatheredAccountRep](g.API, utils.CombineURL(g.API.GetEndpoint(), gatheredAccountListAPI), filter)
+}
+
+// Iter returns an iterator over every gathered account matching filter, fetching the pages on demand.
+func (g *GatheredAccounts) Iter(filter *GatheredAccountFilter) *apiauth.Iterator[GatheredAccountRep] {
+	return apiauth.NewQueryIterator[GatheredAccountRep](g.API, utils.CombineURL(g.API.GetEndpoint(), gatheredAccountListAPI), filter)
+}
+
+// Sync adds the gathered accounts identified by ids to the managed accounts of their assets.
+func (g *GatheredAccounts) Sync(ids ...string) error {
+	return g.SyncWithContext(context.Background(), ids...)
+}
+
+// SyncWithContext is the context-aware variant of Sync.
+func (g *GatheredAccounts) SyncWithContext(ctx context.Context, ids ...string) error {
+	// check ids
+	if len(ids) == 0 {
+		return fmt.Errorf("gathered account ids can not empty")
+	}
+	for _, id := range ids {
+		if id == "" {
+			return fmt.Errorf("gathered account id can not empty")
+		}
+	}
+	return sendAutomation(ctx, g.API, http.MethodPost, gatheredAccountSyncAPI, "", map[string][]string{"gathered_account_ids": ids}, nil)
+}
+
+// Delete deletes the gathered account identified by id, it is discovered again by the next execution
+// as long as it exists on the asset.
+func (g *GatheredAccounts) Delete(id string) error {
+	return g.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is the context-aware variant of Delete.
+func (g *GatheredAccounts) DeleteWithContext(ctx context.Context, id string) error {
+	// check id
+	if id == "" {
+		return fmt.Errorf("gathered account id can not empty")
+	}
+	return sendAutomation(ctx, g.API, http.MethodDelete, gatheredAccountGetAPI, id, nil, nil)
+}
@@ -0,0 +1,199 @@
+package accouts
+
+import (
+	"context"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+	"time"
+)
+
+// PushAccountAutomation is a struct that holds configuration for the JmsAPI.
+// It is used to manage the push account automations, which create the accounts known to JumpServer
+// on the assets themselves, and their executions.
+type PushAccountAutomation struct {
+	API apiauth.JmsAPI
+}
+
+// Get retrieves the push account automation identified by id.
+func (p *PushAccountAutomation) Get(id string) (*AccountAutomationRep, error) {
+	return p.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is the context-aware variant of Get.
+func (p *PushAccountAutomation) GetWithContext(ctx context.Context, id string) (*AccountAutomationRep, error) {
+	rep := &AccountAutomationRep{}
+	err := sendAutomation(ctx, p.API, http.MethodGet, pushAccountGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// List retrieves one page of the push account automations matching filter, or every automation when filter sets no Limit.
+func (p *PushAccountAutomation) List(filter *AutomationFilter) (*AccountAutomationListRep, error) {
+	return p.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is the context-aware variant of List.
+func (p *PushAccountAutomation) ListWithContext(ctx context.Context, filter *AutomationFilter) (*AccountAutomationListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), pushAccountListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[AccountAutomationRep](ctx, p.API, endpoint, v)
+}
+
+// ListAll fetches every page of the push account automations matching filter and returns all of them.
+func (p *PushAccountAutomation) ListAll(filter *AutomationFilter) ([]AccountAutomationRep, error) {
+	return p.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is the context-aware variant of ListAll.
+func (p *PushAccountAutomation) ListAllWithContext(ctx context.Context, filter *AutomationFilter) ([]AccountAutomationRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), pushAccountListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[AccountAutomationRep](ctx, p.API, endpoint, v)
+}
+
+// Pages returns a pager over the push account automations matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (p *PushAccountAutomation) Pages(filter *AutomationFilter) *apiauth.Pager[AccountAutomationRep] {
+	return apiauth.NewQueryPager[AccountAutomationRep](p.API, utils.CombineURL(p.API.GetEndpoint(), pushAccountListAPI), filter)
+}
+
+// Iter returns an iterator over every push account automation matching filter, fetching the pages on demand.
+func (p *PushAccountAutomation) Iter(filter *AutomationFilter) *apiauth.Iterator[AccountAutomationRep] {
+	return apiauth.NewQueryIterator[AccountAutomationRep](p.API, utils.CombineURL(p.API.GetEndpoint(), pushAccountListAPI), filter)
+}
+
+// Create creates a push account automation from data and returns it.
+func (p *PushAccountAutomation) Create(data *AccountAutomationReq) (*AccountAutomationRep, error) {
+	return p.CreateWithContext(context.Background(), data)
+}
+
+// CreateWithContext is the context-aware variant of Create.
+func (p *PushAccountAutomation) CreateWithContext(ctx context.Context, data *AccountAutomationReq) (*AccountAutomationRep, error) {
+	rep := &AccountAutomationRep{}
+	err := sendAutomation(ctx, p.API, http.MethodPost, pushAccountListAPI, "", data, rep)
+	return rep, err
+}
+
+// Update replaces the push account automation identified by id with data and returns it.
+func (p *PushAccountAutomation) Update(id string, data *AccountAutomationReq) (*AccountAutomationRep, error) {
+	return p.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is the context-aware variant of Update.
+func (p *PushAccountAutomation) UpdateWithContext(ctx context.Context, id string, data *AccountAutomationReq) (*AccountAutomationRep, error) {
+	rep := &AccountAutomationRep{}
+	err := sendAutomation(ctx, p.API, http.MethodPut, pushAccountGetAPI, id, data, rep)
+	return rep, err
+}
+
+// Delete deletes the push account automation identified by id together with its executions.
+func (p *PushAccountAutomation) Delete(id string) error {
+	return p.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is the context-aware variant of Delete.
+func (p *PushAccountAutomation) DeleteWithContext(ctx context.Context, id string) error {
+	return sendAutomation(ctx, p.API, http.MethodDelete, pushAccountGetAPI, id, nil, nil)
+}
+
+// Execute starts an execution of the push account automation identified by id and returns the execution id.
+func (p *PushAccountAutomation) Execute(id string) (string, error) {
+	return p.ExecuteWithContext(context.Background(), id)
+}
+
+// ExecuteWithContext is the context-aware variant of Execute.
+func (p *PushAccountAutomation) ExecuteWithContext(ctx context.Context, id string) (string, error) {
+	return executeAutomation(ctx, p.API, pushAccountExecutionListAPI, id)
+}
+
+// Execution retrieves the push account execution identified by id.
+func (p *PushAccountAutomation) Execution(id string) (*ExecutionRep, error) {
+	return p.ExecutionWithContext(context.Background(), id)
+}
+
+// ExecutionWithContext is the context-aware variant of Execution.
+func (p *PushAccountAutomation) ExecutionWithContext(ctx context.Context, id string) (*ExecutionRep, error) {
+	rep := &ExecutionRep{}
+	err := sendAutomation(ctx, p.API, http.MethodGet, pushAccountExecutionGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// Executions retrieves one page of the push account executions matching filter, newest first.
+func (p *PushAccountAutomation) Executions(filter *ExecutionFilter) (*ExecutionListRep, error) {
+	return p.ExecutionsWithContext(context.Background(), filter)
+}
+
+// ExecutionsWithContext is the context-aware variant of Executions.
+func (p *PushAccountAutomation) ExecutionsWithContext(ctx context.Context, filter *ExecutionFilter) (*ExecutionListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), pushAccountExecutionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[ExecutionRep](ctx, p.API, endpoint, v)
+}
+
+// ExecutionsAll fetches every page of the push account executions matching filter, newest first.
+func (p *PushAccountAutomation) ExecutionsAll(filter *ExecutionFilter) ([]ExecutionRep, error) {
+	return p.ExecutionsAllWithContext(context.Background(), filter)
+}
+
+// ExecutionsAllWithContext is the context-aware variant of ExecutionsAll.
+func (p *PushAccountAutomation) ExecutionsAllWithContext(ctx context.Context, filter *ExecutionFilter) ([]ExecutionRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), pushAccountExecutionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[ExecutionRep](ctx, p.API, endpoint, v)
+}
+
+// ExecutionPages returns a pager over the push account executions matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (p *PushAccountAutomation) ExecutionPages(filter *ExecutionFilter) *apiauth.Pager[ExecutionRep] {
+	return apiauth.NewQueryPager[ExecutionRep](p.API, utils.CombineURL(p.API.GetEndpoint(), pushAccountExecutionListAPI), filter)
+}
+
+// ExecutionIter returns an iterator over every push account execution matching filter, fetching the pages on demand.
+func (p *PushAccountAutomation) ExecutionIter(filter *ExecutionFilter) *apiauth.Iterator[ExecutionRep] {
+	return apiauth.NewQueryIterator[ExecutionRep](p.API, utils.CombineURL(p.API.GetEndpoint(), pushAccountExecutionListAPI), filter)
+}
+
+// Wait polls the push account execution identified by id every interval until it is finished and returns it.
+// A zero interval polls every 3 seconds. The execution only exists once a worker picks its task, the not found
+// error is returned when it is still missing after 20 polls, such as for a wrong id.
+func (p *PushAccountAutomation) Wait(id string, interval time.Duration) (*ExecutionRep, error) {
+	return p.WaitWithContext(context.Background(), id, interval)
+}
+
+// WaitWithContext is like Wait but gives up with the error of ctx once it is done.
+func (p *PushAccountAutomation) WaitWithContext(ctx context.Context, id string, interval time.Duration) (*ExecutionRep, error) {
+	return waitExecution(ctx, p.API, pushAccountExecutionGetAPI, id, interval)
+}
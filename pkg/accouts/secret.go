@@ -0,0 +1,79 @@
+package accouts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"io"
+	"net/http"
+)
+
+// Secret holds the secret of an account, see utils.Secret.
+type Secret = utils.Secret
+
+// NewSecret returns a Secret owning b, to be set in a request body.
+// b must not be used by the caller afterwards, it is zeroed together with the Secret.
+func NewSecret(b []byte) *Secret {
+	return utils.NewSecret(b)
+}
+
+// AccountSecretRep is an account together with its secret.
+// The caller must call Secret.Zero once the secret has been used.
+type AccountSecretRep struct {
+	AccountDetailRep
+	Secret Secret `json:"secret"`
+}
+
+// Secret retrieves the account identified by id together with its secret.
+// JumpServer records every secret retrieval in its operate logs, and the API user needs the
+// 'view account secret' permission. The response body is never printed in debug mode, and the
+// buffer it was read into is zeroed once decoded.
+func (a *Account) Secret(id string) (*AccountSecretRep, error) {
+	return a.SecretWithContext(context.Background(), id)
+}
+
+// SecretWithContext is the context-aware variant of Secret.
+func (a *Account) SecretWithContext(ctx context.Context, id string) (*AccountSecretRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("account id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(a.API.GetEndpoint(), fmt.Sprintf(accountSecretAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, a.API, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// do raw request, DoRequest would print the secret in debug mode
+	resp, err := apiauth.DoRawRequest(a.API, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	defer zero(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// decode response body
+	data := &AccountSecretRep{}
+	if err := json.Unmarshal(body, data); err != nil {
+		data.Secret.Zero()
+		return nil, fmt.Errorf("decode account secret error: %s", err)
+	}
+	return data, nil
+}
+
+// zero overwrites b with zeros.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
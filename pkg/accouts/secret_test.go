@@ -0,0 +1,103 @@
+package accouts
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/bytedance/sonic"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAccountSecret(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/accounts/account-secrets/a1/" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"a1","name":"root","username":"root","secret":"P@ss\nw0rd"}`))
+	}))
+	defer srv.Close()
+
+	a := &Account{API: &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token", Debug: true}}
+	if _, err := a.Secret(""); err == nil {
+		t.Errorf("Secret(\"\") error = nil")
+	}
+
+	// the debug mode must not print the response body
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	rep, err := a.Secret("a1")
+	os.Stdout = stdout
+	w.Close()
+	printed, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(printed), "P@ss") {
+		t.Errorf("debug output = %s, secret leaked", printed)
+	}
+	if rep.Id != "a1" || rep.Username != "root" || string(rep.Secret.Bytes()) != "P@ss\nw0rd" {
+		t.Errorf("Secret() = %+v, %q", rep.AccountDetailRep, rep.Secret.Bytes())
+	}
+	rep.Secret.Zero()
+	if rep.Secret.Len() != 0 {
+		t.Errorf("Zero() left %d bytes", rep.Secret.Len())
+	}
+}
+
+func TestSecretRequestsWire(t *testing.T) {
+	// the newline is escaped as \u000a by Secret.MarshalJSON and as \n by the sonic string encoder
+	const secret, passphrase = "P@ss\nw0rd", "unlock-me"
+	tests := []struct {
+		name string
+		req  func() interface{}
+	}{
+		{name: "account", req: func() interface{} {
+			return &AccountReq{Name: "root", Username: "root", SecretType: SecretTypeSSHKey,
+				Secret: NewSecret([]byte(secret)), Passphrase: NewSecret([]byte(passphrase))}
+		}},
+		{name: "template", req: func() interface{} {
+			return &TemplateReq{Name: "root", Username: "root", SecretType: SecretTypeSSHKey, SecretStrategy: "specific",
+				Secret: NewSecret([]byte(secret)), Passphrase: NewSecret([]byte(passphrase))}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req()
+			body, err := sonic.Marshal(req)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if !bytes.Contains(body, []byte(`"secret":"P@ss\u000aw0rd"`)) || !bytes.Contains(body, []byte(`"passphrase":"unlock-me"`)) {
+				t.Errorf("Marshal() = %s, want the secrets encoded by Secret.MarshalJSON", body)
+			}
+			if n := bytes.Count(body, []byte("P@ss")); n != 1 {
+				t.Errorf("Marshal() = %s, secret sent %d times", body, n)
+			}
+			if n := bytes.Count(body, []byte(passphrase)); n != 1 {
+				t.Errorf("Marshal() = %s, passphrase sent %d times", body, n)
+			}
+
+			for _, verb := range []string{"%v", "%+v", "%#v"} {
+				out := fmt.Sprintf(verb, req)
+				if strings.Contains(out, "P@ss") || strings.Contains(out, passphrase) {
+					t.Errorf("Sprintf(%q) = %s, secret leaked", verb, out)
+				}
+			}
+			var logs bytes.Buffer
+			slog.New(slog.NewTextHandler(&logs, nil)).Info("request", "req", req, "secret", NewSecret([]byte(secret)))
+			if strings.Contains(logs.String(), "P@ss") || !strings.Contains(logs.String(), "secret=[REDACTED]") {
+				t.Errorf("slog = %s, secret leaked", logs.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package accouts
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+)
+
+const (
+	// SecretStrategySpecific uses the secret given in the request.
+	SecretStrategySpecific = "specific"
+	// SecretStrategyRandom generates a random secret following the password rules.
+	SecretStrategyRandom = "random"
+)
+
+// Templates is a struct that holds configuration for the JmsAPI.
+// It is used to manage the account templates, the standard accounts added to many assets.
+type Templates struct {
+	API apiauth.JmsAPI
+}
+
+// PasswordRules are the rules of the passwords generated by the SecretStrategyRandom strategy.
+// ExcludeSymbols lists the symbols never used, for example quotes the assets would choke on.
+type PasswordRules struct {
+	Length         int    `json:"length"`
+	Lowercase      bool   `json:"lowercase"`
+	Uppercase      bool   `json:"uppercase"`
+	Digit          bool   `json:"digit"`
+	Symbol         bool   `json:"symbol"`
+	ExcludeSymbols string `json:"exclude_symbols,omitempty"`
+}
+
+// TemplateRep represents an account template. The server never returns the template secret.
+type TemplateRep struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Username   string `json:"username"`
+	SecretType struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"secret_type"`
+	SecretStrategy struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"secret_strategy"`
+	PasswordRules PasswordRules `json:"password_rules"`
+	HasSecret     bool          `json:"has_secret"`
+	Privileged    bool          `json:"privileged"`
+	IsActive      bool          `json:"is_active"`
+	AutoPush      bool          `json:"auto_push"`
+	SuFrom        *struct {
+		Id       string `json:"id"`
+		Name     string `json:"name"`
+		Username string `json:"username"`
+	} `json:"su_from"`
+	Comment     string `json:"comment"`
+	OrgId       string `json:"org_id"`
+	OrgName     string `json:"org_name"`
+	CreatedBy   string `json:"created_by"`
+	DateCreated string `json:"date_created"`
+	DateUpdated string `json:"date_updated"`
+}
+
+// TemplateListRep is the paginated response of the account templates list endpoint.
+type TemplateListRep = apiauth.ListRep[TemplateRep]
+
+// TemplateReq is the request body used to create or update an account template.
+// SecretStrategy is one of the SecretStrategyXxx constants: Secret is required by SecretStrategySpecific
+// and PasswordRules used by SecretStrategyRandom. SuFrom is the id of another template.
+// When AutoPush is set the accounts created from the template are also pushed to the assets.
+// IsActive is left to the server default, active, when nil.
+type TemplateReq struct {
+	Name           string         `json:"name"`
+	Username       string         `json:"username"`
+	SecretType     string         `json:"secret_type"`
+	SecretStrategy string         `json:"secret_strategy"`
+	Secret         *Secret        `json:"secret,omitempty"`
+	Passphrase     *Secret        `json:"passphrase,omitempty"`
+	PasswordRules  *PasswordRules `json:"password_rules,omitempty"`
+	Privileged     bool           `json:"privileged"`
+	IsActive       *bool          `json:"is_active,omitempty"`
+	AutoPush       bool           `json:"auto_push"`
+	SuFrom         string         `json:"su_from,omitempty"`
+	Comment        string         `json:"comment,omitempty"`
+}
+
+// TemplateApplyReq describes the assets an account template is applied to, every asset of Assets
+// and of the nodes of Nodes. OnInvalid is one of the OnInvalidXxx constants.
+type TemplateApplyReq struct {
+	Assets    []string `json:"assets,omitempty"`
+	Nodes     []string `json:"nodes,omitempty"`
+	OnInvalid string   `json:"on_invalid"`
+	PushNow   bool     `json:"push_now"`
+}
+
+// Get retrieves the account template identified by id.
+func (t *Templates) Get(id string) (*TemplateRep, error) {
+	return t.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is the context-aware variant of Get.
+func (t *Templates) GetWithContext(ctx context.Context, id string) (*TemplateRep, error) {
+	rep := &TemplateRep{}
+	err := t.send(ctx, http.MethodGet, id, nil, rep)
+	return rep, err
+}
+
+// List retrieves one page of the account templates matching filter, or every template when filter sets no Limit.
+func (t *Templates) List(filter *TemplateFilter) (*TemplateListRep, error) {
+	return t.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is the context-aware variant of List.
+func (t *Templates) ListWithContext(ctx context.Context, filter *TemplateFilter) (*TemplateListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), templatesListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[TemplateRep](ctx, t.API, endpoint, v)
+}
+
+// ListAll fetches every page of the account templates matching filter and returns all of them.
+func (t *Templates) ListAll(filter *TemplateFilter) ([]TemplateRep, error) {
+	return t.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is the context-aware variant of ListAll.
+func (t *Templates) ListAllWithContext(ctx context.Context, filter *TemplateFilter) ([]TemplateRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), templatesListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[TemplateRep](ctx, t.API, endpoint, v)
+}
+
+// Pages returns a pager over the account templates matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (t *Templates) Pages(filter *TemplateFilter) *apiauth.Pager[TemplateRep] {
+	return apiauth.NewQueryPager[TemplateRep](t.API, utils.CombineURL(t.API.GetEndpoint(), templatesListAPI), filter)
+}
+
+// Iter returns an iterator over every account template matching filter, fetching the pages on demand.
+func (t *Templates) Iter(filter *TemplateFilter) *apiauth.Iterator[TemplateRep] {
+	return apiauth.NewQueryIterator[TemplateRep](t.API, utils.CombineURL(t.API.GetEndpoint(), templatesListAPI), filter)
+}
+
+// Create creates an account template from data and returns it.
+func (t *Templates) Create(data *TemplateReq) (*TemplateRep, error) {
+	return t.CreateWithContext(context.Background(), data)
+}
+
+// CreateWithContext is the context-aware variant of Create.
+func (t *Templates) CreateWithContext(ctx context.Context, data *TemplateReq) (*TemplateRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), templatesListAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, t.API, http.MethodPost, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	rep := &TemplateRep{}
+	err = t.API.DoRequest(req, rep)
+	return rep, err
+}
+
+// Update replaces the account template identified by id with data and returns it.
+// The accounts already created from the template are not changed.
+func (t *Templates) Update(id string, data *TemplateReq) (*TemplateRep, error) {
+	return t.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is the context-aware variant of Update.
+func (t *Templates) UpdateWithContext(ctx context.Context, id string, data *TemplateReq) (*TemplateRep, error) {
+	rep := &TemplateRep{}
+	err := t.send(ctx, http.MethodPut, id, data, rep)
+	return rep, err
+}
+
+// Delete deletes the account template identified by id, the accounts created from it are kept.
+func (t *Templates) Delete(id string) error {
+	return t.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is the context-aware variant of Delete.
+func (t *Templates) DeleteWithContext(ctx context.Context, id string) error {
+	return t.send(ctx, http.MethodDelete, id, nil, nil)
+}
+
+// Apply creates the account described by the template identified by id on every asset targeted by data
+// and returns the outcome per asset, like Account.BulkCreate.
+func (t *Templates) Apply(id string, data *TemplateApplyReq) ([]AccountBulkResult, error) {
+	return t.ApplyWithContext(context.Background(), id, data)
+}
+
+// ApplyWithContext is the context-aware variant of Apply.
+func (t *Templates) ApplyWithContext(ctx context.Context, id string, data *TemplateApplyReq) ([]AccountBulkResult, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("template id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), accountsBulkAPI)
+
+	// make request, the server fills the account fields from the template
+	body := struct {
+		Template string `json:"template"`
+		*TemplateApplyReq
+	}{Template: id, TemplateApplyReq: data}
+	req, err := apiauth.MakeRequestWithContext(ctx, t.API, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	rep := make([]AccountBulkResult, 0)
+	err = t.API.DoRequest(req, &rep)
+	return rep, err
+}
+
+// send sends data with method to the detail endpoint of the account template identified by id
+// and decodes the response into result.
+func (t *Templates) send(ctx context.Context, method, id string, data, result interface{}) error {
+	// check id
+	if id == "" {
+		return fmt.Errorf("template id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), fmt.Sprintf(templateGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, t.API, method, endpoint, data)
+	if err != nil {
+		return err
+	}
+
+	// do request
+	return t.API.DoRequest(req, result)
+}
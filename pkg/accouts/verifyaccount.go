@@ -0,0 +1,277 @@
+package accouts
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+	"time"
+)
+
+// VerifyAccountAutomation is a struct that holds configuration for the JmsAPI.
+// It is used to manage the verify account automations, which log in to the assets with the accounts
+// known to JumpServer and record the outcome as the Connectivity of every account, and their executions.
+type VerifyAccountAutomation struct {
+	API apiauth.JmsAPI
+}
+
+// Get retrieves the verify account automation identified by id.
+func (a *VerifyAccountAutomation) Get(id string) (*AccountAutomationRep, error) {
+	return a.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is the context-aware variant of Get.
+func (a *VerifyAccountAutomation) GetWithContext(ctx context.Context, id string) (*AccountAutomationRep, error) {
+	rep := &AccountAutomationRep{}
+	err := sendAutomation(ctx, a.API, http.MethodGet, verifyAccountGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// List retrieves one page of the verify account automations matching filter, or every automation when filter sets no Limit.
+func (a *VerifyAccountAutomation) List(filter *AutomationFilter) (*AccountAutomationListRep, error) {
+	return a.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is the context-aware variant of List.
+func (a *VerifyAccountAutomation) ListWithContext(ctx context.Context, filter *AutomationFilter) (*AccountAutomationListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(a.API.GetEndpoint(), verifyAccountListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[AccountAutomationRep](ctx, a.API, endpoint, v)
+}
+
+// ListAll fetches every page of the verify account automations matching filter and returns all of them.
+func (a *VerifyAccountAutomation) ListAll(filter *AutomationFilter) ([]AccountAutomationRep, error) {
+	return a.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is the context-aware variant of ListAll.
+func (a *VerifyAccountAutomation) ListAllWithContext(ctx context.Context, filter *AutomationFilter) ([]AccountAutomationRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(a.API.GetEndpoint(), verifyAccountListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[AccountAutomationRep](ctx, a.API, endpoint, v)
+}
+
+// Pages returns a pager over the verify account automations matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (a *VerifyAccountAutomation) Pages(filter *AutomationFilter) *apiauth.Pager[AccountAutomationRep] {
+	return apiauth.NewQueryPager[AccountAutomationRep](a.API, utils.CombineURL(a.API.GetEndpoint(), verifyAccountListAPI), filter)
+}
+
+// Iter returns an iterator over every verify account automation matching filter, fetching the pages on demand.
+func (a *VerifyAccountAutomation) Iter(filter *AutomationFilter) *apiauth.Iterator[AccountAutomationRep] {
+	return apiauth.NewQueryIterator[AccountAutomationRep](a.API, utils.CombineURL(a.API.GetEndpoint(), verifyAccountListAPI), filter)
+}
+
+// Create creates a verify account automation from data and returns it.
+func (a *VerifyAccountAutomation) Create(data *AccountAutomationReq) (*AccountAutomationRep, error) {
+	return a.CreateWithContext(context.Background(), data)
+}
+
+// CreateWithContext is the context-aware variant of Create.
+func (a *VerifyAccountAutomation) CreateWithContext(ctx context.Context, data *AccountAutomationReq) (*AccountAutomationRep, error) {
+	rep := &AccountAutomationRep{}
+	err := sendAutomation(ctx, a.API, http.MethodPost, verifyAccountListAPI, "", data, rep)
+	return rep, err
+}
+
+// Update replaces the verify account automation identified by id with data and returns it.
+func (a *VerifyAccountAutomation) Update(id string, data *AccountAutomationReq) (*AccountAutomationRep, error) {
+	return a.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is the context-aware variant of Update.
+func (a *VerifyAccountAutomation) UpdateWithContext(ctx context.Context, id string, data *AccountAutomationReq) (*AccountAutomationRep, error) {
+	rep := &AccountAutomationRep{}
+	err := sendAutomation(ctx, a.API, http.MethodPut, verifyAccountGetAPI, id, data, rep)
+	return rep, err
+}
+
+// Delete deletes the verify account automation identified by id together with its executions.
+func (a *VerifyAccountAutomation) Delete(id string) error {
+	return a.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is the context-aware variant of Delete.
+func (a *VerifyAccountAutomation) DeleteWithContext(ctx context.Context, id string) error {
+	return sendAutomation(ctx, a.API, http.MethodDelete, verifyAccountGetAPI, id, nil, nil)
+}
+
+// Execute starts an execution of the verify account automation identified by id and returns the execution id.
+func (a *VerifyAccountAutomation) Execute(id string) (string, error) {
+	return a.ExecuteWithContext(context.Background(), id)
+}
+
+// ExecuteWithContext is the context-aware variant of Execute.
+func (a *VerifyAccountAutomation) ExecuteWithContext(ctx context.Context, id string) (string, error) {
+	return executeAutomation(ctx, a.API, verifyAccountExecutionListAPI, id)
+}
+
+// Execution retrieves the verify account execution identified by id.
+func (a *VerifyAccountAutomation) Execution(id string) (*ExecutionRep, error) {
+	return a.ExecutionWithContext(context.Background(), id)
+}
+
+// ExecutionWithContext is the context-aware variant of Execution.
+func (a *VerifyAccountAutomation) ExecutionWithContext(ctx context.Context, id string) (*ExecutionRep, error) {
+	rep := &ExecutionRep{}
+	err := sendAutomation(ctx, a.API, http.MethodGet, verifyAccountExecutionGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// Executions retrieves one page of the verify account executions matching filter, newest first.
+func (a *VerifyAccountAutomation) Executions(filter *ExecutionFilter) (*ExecutionListRep, error) {
+	return a.ExecutionsWithContext(context.Background(), filter)
+}
+
+// ExecutionsWithContext is the context-aware variant of Executions.
+func (a *VerifyAccountAutomation) ExecutionsWithContext(ctx context.Context, filter *ExecutionFilter) (*ExecutionListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(a.API.GetEndpoint(), verifyAccountExecutionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[ExecutionRep](ctx, a.API, endpoint, v)
+}
+
+// ExecutionsAll fetches every page of the verify account executions matching filter, newest first.
+func (a *VerifyAccountAutomation) ExecutionsAll(filter *ExecutionFilter) ([]ExecutionRep, error) {
+	return a.ExecutionsAllWithContext(context.Background(), filter)
+}
+
+// ExecutionsAllWithContext is the context-aware variant of ExecutionsAll.
+func (a *VerifyAccountAutomation) ExecutionsAllWithContext(ctx context.Context, filter *ExecutionFilter) ([]ExecutionRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(a.API.GetEndpoint(), verifyAccountExecutionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[ExecutionRep](ctx, a.API, endpoint, v)
+}
+
+// ExecutionPages returns a pager over the verify account executions matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (a *VerifyAccountAutomation) ExecutionPages(filter *ExecutionFilter) *apiauth.Pager[ExecutionRep] {
+	return apiauth.NewQueryPager[ExecutionRep](a.API, utils.CombineURL(a.API.GetEndpoint(), verifyAccountExecutionListAPI), filter)
+}
+
+// ExecutionIter returns an iterator over every verify account execution matching filter, fetching the pages on demand.
+func (a *VerifyAccountAutomation) ExecutionIter(filter *ExecutionFilter) *apiauth.Iterator[ExecutionRep] {
+	return apiauth.NewQueryIterator[ExecutionRep](a.API, utils.CombineURL(a.API.GetEndpoint(), verifyAccountExecutionListAPI), filter)
+}
+
+// Wait polls the verify account execution identified by id every interval until it is finished and returns it.
+// A zero interval polls every 3 seconds. The execution only exists once a worker picks its task, the not found
+// error is returned when it is still missing after 20 polls, such as for a wrong id.
+func (a *VerifyAccountAutomation) Wait(id string, interval time.Duration) (*ExecutionRep, error) {
+	return a.WaitWithContext(context.Background(), id, interval)
+}
+
+// WaitWithContext is like Wait but gives up with the error of ctx once it is done.
+func (a *VerifyAccountAutomation) WaitWithContext(ctx context.Context, id string, interval time.Duration) (*ExecutionRep, error) {
+	return waitExecution(ctx, a.API, verifyAccountExecutionGetAPI, id, interval)
+}
+
+// VerifyResult is the outcome of the verification of an account by an execution.
+// Verified is false when the account was not verified since the start of the execution,
+// the Connectivity of Account is then the one of an older verification.
+type VerifyResult struct {
+	Account  AccountDetailRep
+	Verified bool
+}
+
+// OK reports whether the account was verified by the execution and its secret works.
+func (r *VerifyResult) OK() bool {
+	return r.Verified && r.Account.Connectivity.Value == ConnectivityOK
+}
+
+// Results returns the verification outcome of every account targeted by the finished verify account
+// execution identified by id. The server keeps no per-account record of the verifications, so the
+// accounts of the targeted assets and nodes are read back and matched on the usernames of the automation.
+func (a *VerifyAccountAutomation) Results(id string) ([]VerifyResult, error) {
+	return a.ResultsWithContext(context.Background(), id)
+}
+
+// ResultsWithContext is the context-aware variant of Results.
+func (a *VerifyAccountAutomation) ResultsWithContext(ctx context.Context, id string) ([]VerifyResult, error) {
+	execution, err := a.ExecutionWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !execution.IsFinished() {
+		return nil, fmt.Errorf("verify account execution %s is not finished", id)
+	}
+	started, err := utils.ParseDate(execution.DateStart)
+	if err != nil {
+		return nil, fmt.Errorf("verify account execution %s start date error: %s", id, err)
+	}
+	automation, err := a.GetWithContext(ctx, execution.Automation)
+	if err != nil {
+		return nil, err
+	}
+
+	// collect the accounts of the targeted assets and nodes
+	account := &Account{API: a.API}
+	filters := make([]*AccountFilter, 0, len(automation.Assets)+len(automation.Nodes))
+	for _, asset := range automation.Assets {
+		filters = append(filters, &AccountFilter{Asset: asset.Id})
+	}
+	for _, node := range automation.Nodes {
+		filters = append(filters, &AccountFilter{NodeID: node.Id})
+	}
+	usernames := make(map[string]bool, len(automation.Accounts))
+	for _, username := range automation.Accounts {
+		usernames[username] = true
+	}
+	seen := make(map[string]bool)
+	results := make([]VerifyResult, 0)
+	for _, filter := range filters {
+		accounts, err := account.ListAllWithContext(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, acc := range accounts {
+			if seen[acc.Id] || !(usernames["@ALL"] || usernames[acc.Username]) {
+				continue
+			}
+			seen[acc.Id] = true
+			verified := false
+			if acc.DateVerified != "" {
+				date, err := utils.ParseDate(acc.DateVerified)
+				if err != nil {
+					return nil, fmt.Errorf("account %s verified date error: %s", acc.Id, err)
+				}
+				verified = !date.Before(started)
+			}
+			results = append(results, VerifyResult{Account: acc, Verified: verified})
+		}
+	}
+	return results, nil
+}
@@ -2,7 +2,7 @@ package apiauth
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -15,9 +15,15 @@ import (
 	"os"
 )
 
+// JmsAKConfig signs the requests with an access key read from Conjur.
+// HTTPOptions configure the requests to JumpServer and Conjur the separate client used to read the access key
+// from the Conjur host, so the JumpServer CA, client certificate, proxy and retry policy are not applied to it.
+// The Conjur certificate is only skipped when Conjur.InsecureSkipVerify is set.
 type JmsAKConfig struct {
-	Endpoints string `json:"endpoints"`
-	Debug     bool   `json:"debug"`
+	Endpoints string      `json:"endpoints"`
+	Debug     bool        `json:"debug"`
+	Conjur    HTTPOptions `json:"conjur"`
+	HTTPOptions
 }
 
 func (j *JmsAKConfig) SignReq(r *http.Request) error {
@@ -36,21 +42,23 @@ func (j *JmsAKConfig) SignReq(r *http.Request) error {
 
 	// ak endpoint
 	akApi := fmt.Sprintf("%s/secrets/lixiang/variable/%s", endpoint, "Prd_Vault/authn/App_JMS-Tools_prd/IT_JumpServer_JMS-Tools/username")
-	req, err := http.NewRequest("GET", akApi, nil)
+	req, err := http.NewRequestWithContext(r.Context(), "GET", akApi, nil)
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Authorization", fmt.Sprintf("Token token=\"%s\"", token))
 
-	// do request
-	client := &http.Client{Transport: &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}}
+	// do request with the conjur client
+	client, err := j.Conjur.httpClient()
+	if err != nil {
+		return err
+	}
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
 	}
 	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
 	if err != nil {
 		return fmt.Errorf("read access token body error: %s", err)
 	}
@@ -61,7 +69,7 @@ func (j *JmsAKConfig) SignReq(r *http.Request) error {
 
 	// sk endpoint
 	skApi := fmt.Sprintf("%s/secrets/lixiang/variable/%s", endpoint, "Prd_Vault/authn/App_JMS-Tools_prd/IT_JumpServer_JMS-Tools/password")
-	req, err = http.NewRequest("GET", skApi, nil)
+	req, err = http.NewRequestWithContext(r.Context(), "GET", skApi, nil)
 	if err != nil {
 		return err
 	}
@@ -73,6 +81,7 @@ func (j *JmsAKConfig) SignReq(r *http.Request) error {
 		return err
 	}
 	body, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
 	if err != nil {
 		return fmt.Errorf("read secrect token body error: %s", err)
 	}
@@ -90,6 +99,10 @@ func (j *JmsAKConfig) SignReq(r *http.Request) error {
 }
 
 func (j *JmsAKConfig) MakeRequest(method, endpoint string, body interface{}) (*http.Request, error) {
+	return j.MakeRequestWithContext(context.Background(), method, endpoint, body)
+}
+
+func (j *JmsAKConfig) MakeRequestWithContext(ctx context.Context, method, endpoint string, body interface{}) (*http.Request, error) {
 	// process body data
 	var bodyReader io.Reader
 	if body != nil {
@@ -101,38 +114,27 @@ func (j *JmsAKConfig) MakeRequest(method, endpoint string, body interface{}) (*h
 	}
 
 	// make request
-	req, err := http.NewRequest(method, endpoint, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("make new request error: %s", err)
 	}
 
-	// set header
+	// set header, the request is signed by DoRequest once the query is final
 	req.Header.Set("Content-Type", "application/json")
-	err = j.SignReq(req)
-	if err != nil {
-		return nil, fmt.Errorf("sign request error: %s", err)
-	}
 	return req, nil
 }
 
 func (j *JmsAKConfig) DoRequest(req *http.Request, result interface{}) error {
-	// do request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-
-	// read response body
-	body, err := io.ReadAll(resp.Body)
+	// do request, the request is signed again before every attempt
+	resp, body, err := j.send(req, func(r *http.Request) error {
+		if err := j.SignReq(r); err != nil {
+			return fmt.Errorf("sign request error: %s", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-		}
-	}(resp.Body)
 
 	// set debug
 	if j.Debug {
@@ -141,7 +143,7 @@ func (j *JmsAKConfig) DoRequest(req *http.Request, result interface{}) error {
 
 	// check response status code
 	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return fmt.Errorf("server response code is not ok, code:%d, content:%s", resp.StatusCode, body)
+		return NewAPIError(resp, body)
 	}
 
 	// check if result is nil
@@ -155,6 +157,25 @@ func (j *JmsAKConfig) DoRequest(req *http.Request, result interface{}) error {
 
 }
 
+func (j *JmsAKConfig) DoRawRequest(req *http.Request) (*http.Response, error) {
+	// do request, the request is signed again before every attempt
+	resp, err := j.stream(req, func(r *http.Request) error {
+		if err := j.SignReq(r); err != nil {
+			return fmt.Errorf("sign request error: %s", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// set debug
+	if j.Debug {
+		fmt.Printf("response status: %s, content type: %s\n", resp.Status, resp.Header.Get("Content-Type"))
+	}
+	return resp, nil
+}
+
 func (j *JmsAKConfig) SetQuery(req *http.Request, v url.Values) *http.Request {
 	// set query
 	req.URL.RawQuery = v.Encode()
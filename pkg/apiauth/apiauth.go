@@ -2,19 +2,21 @@ package apiauth
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/bytedance/sonic"
-	"io"
 	"net/http"
 	"net/url"
 )
 
 // JmsAPIConfig represents the configuration for the JMS API.
-// It contains the information about the endpoints and the authentication token.
+// It contains the information about the endpoints and the authentication token,
+// and the HTTPOptions used to build the shared HTTP client.
 type JmsAPIConfig struct {
 	Endpoints string `json:"endpoints"`
 	Token     string `json:"token"`
 	Debug     bool   `json:"debug"`
+	HTTPOptions
 }
 
 // MakeRequest creates an HTTP request with a specified method, endpoint, and data.
@@ -36,6 +38,13 @@ type JmsAPIConfig struct {
 //	If 'data' is nil, it will proceed to create the new http request with a nil body.
 //	Finally, before returning, it will set "Content-Type" and "Authorization" headers on the created http.Request.
 func (j *JmsAPIConfig) MakeRequest(method, endpoint string, data interface{}) (*http.Request, error) {
+	return j.MakeRequestWithContext(context.Background(), method, endpoint, data)
+}
+
+// MakeRequestWithContext behaves like MakeRequest but binds the returned request to ctx.
+// Cancelling ctx, or reaching its deadline, aborts the request while it is in flight and
+// DoRequest then returns ctx.Err() (for example context.DeadlineExceeded).
+func (j *JmsAPIConfig) MakeRequestWithContext(ctx context.Context, method, endpoint string, data interface{}) (*http.Request, error) {
 	var err error
 	var body = make([]byte, 0)
 
@@ -50,7 +59,7 @@ func (j *JmsAPIConfig) MakeRequest(method, endpoint string, data interface{}) (*
 	}
 
 	// make request
-	req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
@@ -72,29 +81,23 @@ func (j *JmsAPIConfig) MakeRequest(method, endpoint string, data interface{}) (*
 //
 // Returns:
 //
-//	An error which will be non-nil in case of any errors occurred during executing the HTTP request or unmarshalling the response. If the response HTTP status code is not in the range of 200-399, it will return an error stating the response code and body content.
+//	An error which will be non-nil in case of any errors occurred during executing the HTTP request or unmarshalling the response. If the response HTTP status code is not in the range of 200-399, it will return an *APIError carrying the response code and the decoded body content.
 //
 // Implementation:
 //
-//	The function performs the request using a new http.Client object.
+//	The function performs the request using the http.Client built from the embedded HTTPOptions,
+//	retrying transient failures according to HTTPOptions.Retry.
+//	If the request context is cancelled or its deadline is exceeded, the context error is returned as is.
 //	It then reads the response body and checks the status code.
-//	If the code is not in the range of 200-399, an error will be returned including the response code and body content.
+//	If the code is not in the range of 200-399, an *APIError will be returned including the response code and body content.
 //	If the result parameter is not nil, the function will attempt to unmarshal the response body into it using the sonic.Unmarshal function.
 //	The function returns an error from the unmarshal operation if occurred - or nil if the operation was successful.
 func (j *JmsAPIConfig) DoRequest(req *http.Request, result interface{}) error {
 	// do request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-
-	// read response body
-	body, err := io.ReadAll(resp.Body)
+	resp, body, err := j.send(req, nil)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
 	// set debug
 	if j.Debug {
@@ -103,7 +106,7 @@ func (j *JmsAPIConfig) DoRequest(req *http.Request, result interface{}) error {
 
 	// check response status code
 	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return fmt.Errorf("server response code is not ok, code:%d, content:%s", resp.StatusCode, body)
+		return NewAPIError(resp, body)
 	}
 
 	// check if result is nil
@@ -117,6 +120,23 @@ func (j *JmsAPIConfig) DoRequest(req *http.Request, result interface{}) error {
 
 }
 
+// DoRawRequest performs an HTTP request like DoRequest but returns the response with its body unread,
+// which is used to download files. Redirects are followed, and a response status outside the
+// 200-399 range is returned as an *APIError. The caller must close the response body.
+func (j *JmsAPIConfig) DoRawRequest(req *http.Request) (*http.Response, error) {
+	// do request
+	resp, err := j.stream(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// set debug
+	if j.Debug {
+		fmt.Printf("response status: %s, content type: %s\n", resp.Status, resp.Header.Get("Content-Type"))
+	}
+	return resp, nil
+}
+
 // SetQuery is a method on the JmsAPIConfig struct.
 // It receives an http.Request and a set of url.Values as parameters.
 // The method sets the URL query string of the given http.Request based
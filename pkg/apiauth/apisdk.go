@@ -2,6 +2,7 @@ package apiauth
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,6 +23,7 @@ import (
 //	Endpoints: A string field that represents the API endpoints URLs being used by the JmsSDK.
 //	Debug: A boolean field indicating if debug mode is enabled. When true, response bodies are printed to the console.
 //	ConjurFileName: The name of the Conjur file, typically used for API authorization.
+//	HTTPOptions: The settings of the HTTP client shared by every request.
 //
 // The struct fields are serializable to JSON with respective tags provided.
 //
@@ -46,6 +48,7 @@ type JmsSDKConfig struct {
 	Endpoints      string `json:"endpoints"`
 	Debug          bool   `json:"debug"`
 	ConjurFileName string `json:"conjur_file_name"`
+	HTTPOptions
 }
 
 // SignReq is a method that signs an HTTP request. It reads required environment variables,
@@ -143,6 +146,12 @@ func (j *JmsSDKConfig) SignReq(r *http.Request) error {
 //   - It calls the SignReq method to sign the request. If an error occurs during this process, it returns the error.
 //   - Finally, if everything is successful, it returns the prepared HTTP request.
 func (j *JmsSDKConfig) MakeRequest(method, endpoint string, body interface{}) (*http.Request, error) {
+	return j.MakeRequestWithContext(context.Background(), method, endpoint, body)
+}
+
+// MakeRequestWithContext behaves like MakeRequest but binds the request to ctx,
+// so cancelling ctx or reaching its deadline aborts the in-flight call.
+func (j *JmsSDKConfig) MakeRequestWithContext(ctx context.Context, method, endpoint string, body interface{}) (*http.Request, error) {
 	// process body data
 	var bodyReader io.Reader
 	if body != nil {
@@ -158,7 +167,7 @@ func (j *JmsSDKConfig) MakeRequest(method, endpoint string, body interface{}) (*
 	}
 
 	// make request
-	req, err := http.NewRequest(method, endpoint, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("make new request error: %s", err)
 	}
@@ -181,40 +190,28 @@ func (j *JmsSDKConfig) MakeRequest(method, endpoint string, body interface{}) (*
 // Returns:
 //
 //	error: Returns an error if there's an issue sending the request, reading the response body,
-//	closing the response body, the status code of the response is not in the 200-399 range,
+//	closing the response body, the status code of the response is not in the 200-399 range (as an *APIError),
 //	or there's an issue unmarshaling the response body. Otherwise, it returns nil.
 //
 // Process:
-//   - The method first sends the provided HTTP request. If an error occurs during this process, it returns the error.
+//   - The method first signs and sends the provided HTTP request with the client built from HTTPOptions.
+//     Failed attempts are retried according to HTTPOptions.Retry, and the request is signed again before each one. If an error occurs during this process, it returns the error.
 //   - It reads the response body. If an error occurs during this process, it returns the error.
 //   - The method ensures that the response body is closed when all the processing on it has been done.
 //   - If the Debug field of the JmsSDKConfig struct is true, it prints the response body to the console.
 //   - It checks the status code of the response. If it is not in the 200-399 range, it
 func (j *JmsSDKConfig) DoRequest(req *http.Request, result interface{}) error {
-	// sign request
-	err := j.SignReq(req)
-	if err != nil {
-		return fmt.Errorf("sign request error: %s", err)
-	}
-
-	// do request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	// do request, the request is signed again before every attempt
+	resp, body, err := j.send(req, func(r *http.Request) error {
+		if err := j.SignReq(r); err != nil {
+			return fmt.Errorf("sign request error: %s", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	// read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-		}
-	}(resp.Body)
-
 	// set debug
 	if j.Debug {
 		fmt.Printf("response body: %s\n", body)
@@ -222,7 +219,7 @@ func (j *JmsSDKConfig) DoRequest(req *http.Request, result interface{}) error {
 
 	// check response status code
 	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return fmt.Errorf("server response code is not ok, code:%d, content:%s", resp.StatusCode, body)
+		return NewAPIError(resp, body)
 	}
 
 	// check if result is nil
@@ -236,6 +233,29 @@ func (j *JmsSDKConfig) DoRequest(req *http.Request, result interface{}) error {
 
 }
 
+// DoRawRequest is a method that signs and sends the provided HTTP request like DoRequest, but returns
+// the response with its body unread so that files can be streamed. Redirects are followed and a
+// response status outside the 200-399 range is returned as an *APIError.
+// The caller must close the response body.
+func (j *JmsSDKConfig) DoRawRequest(req *http.Request) (*http.Response, error) {
+	// do request, the request is signed again before every attempt
+	resp, err := j.stream(req, func(r *http.Request) error {
+		if err := j.SignReq(r); err != nil {
+			return fmt.Errorf("sign request error: %s", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// set debug
+	if j.Debug {
+		fmt.Printf("response status: %s, content type: %s\n", resp.Status, resp.Header.Get("Content-Type"))
+	}
+	return resp, nil
+}
+
 // SetQuery is a method that sets the provided query parameters on the given HTTP request.
 // The provided query parameters must be of url.Values type.
 // It then returns the modified HTTP request with the set query parameters.
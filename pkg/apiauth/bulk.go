@@ -0,0 +1,55 @@
+package apiauth
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/utils"
+	"net/http"
+	"net/url"
+)
+
+// CacheResources stores ids in the JumpServer resource cache and returns the 'spm' key referencing them.
+// The key is passed as the 'spm' query parameter of the bulk endpoints, for example to bulk delete
+// the resources of a list endpoint.
+func CacheResources(ctx context.Context, api JmsAPI, ids []string) (string, error) {
+	// check ids
+	if len(ids) == 0 {
+		return "", fmt.Errorf("resource ids can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(api.GetEndpoint(), resourcesCacheAPI)
+
+	// make request
+	req, err := MakeRequestWithContext(ctx, api, http.MethodPost, endpoint, map[string][]string{"resources": ids})
+	if err != nil {
+		return "", err
+	}
+
+	// do request
+	data := &struct {
+		Spm string `json:"spm"`
+	}{}
+	err = api.DoRequest(req, data)
+	return data.Spm, err
+}
+
+// DeleteResources bulk deletes the resources ids of the list endpoint.
+// The ids are first stored with CacheResources, then a DELETE request is sent to endpoint with the 'spm' key.
+func DeleteResources(ctx context.Context, api JmsAPI, endpoint string, ids []string) error {
+	// cache resource ids
+	spm, err := CacheResources(ctx, api, ids)
+	if err != nil {
+		return err
+	}
+
+	// make request
+	req, err := MakeRequestWithContext(ctx, api, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req = api.SetQuery(req, url.Values{"spm": []string{spm}})
+
+	// do request
+	return api.DoRequest(req, nil)
+}
@@ -0,0 +1,126 @@
+package apiauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// clientMu guards the lazy construction of the shared http.Client of every HTTPOptions.
+// A package level lock is used so the API configs stay copyable by value.
+var clientMu sync.Mutex
+
+// HTTPOptions holds the transport settings shared by JmsAPIConfig, JmsAKConfig and JmsSDKConfig.
+// It is embedded in each config, so its fields can be set directly on the config.
+//
+// Properties:
+//
+//	HTTPClient: A fully configured client to use as is. When set, every other option but Retry is ignored.
+//	Transport: A custom RoundTripper used by the built client. When set, the TLS and proxy options are ignored.
+//	Timeout: The overall timeout of a single HTTP request, zero means no timeout.
+//	CAFile: Path of a PEM bundle appended to the system root CAs to verify the server certificate.
+//	CertFile, KeyFile: Paths of a PEM client certificate and its private key for mutual TLS.
+//	ProxyURL: URL of the HTTP proxy. When empty, the proxy is taken from the environment.
+//	InsecureSkipVerify: Explicit opt-in to skip the server certificate verification.
+//	Retry: The policy used to retry transient failures, see RetryPolicy.
+//
+// The client is built on first use and reused by every following request, so keep-alive
+// connections are pooled across calls.
+type HTTPOptions struct {
+	HTTPClient         *http.Client      `json:"-"`
+	Transport          http.RoundTripper `json:"-"`
+	Timeout            time.Duration     `json:"timeout"`
+	CAFile             string            `json:"ca_file"`
+	CertFile           string            `json:"cert_file"`
+	KeyFile            string            `json:"key_file"`
+	ProxyURL           string            `json:"proxy_url"`
+	InsecureSkipVerify bool              `json:"insecure_skip_verify"`
+	Retry              RetryPolicy       `json:"retry"`
+
+	client *http.Client
+}
+
+// Client returns the shared http.Client built from the options, for requests that must be sent
+// without the JumpServer authentication, such as downloads from a pre-signed object storage URL.
+func (o *HTTPOptions) Client() (*http.Client, error) {
+	return o.httpClient()
+}
+
+// httpClient returns the client used to send requests, building and caching it on first use.
+func (o *HTTPOptions) httpClient() (*http.Client, error) {
+	if o.HTTPClient != nil {
+		return o.HTTPClient, nil
+	}
+
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	if o.client != nil {
+		return o.client, nil
+	}
+
+	// build transport
+	transport := o.Transport
+	if transport == nil {
+		t, err := o.buildTransport()
+		if err != nil {
+			return nil, err
+		}
+		transport = t
+	}
+
+	o.client = &http.Client{
+		Transport: transport,
+		Timeout:   o.Timeout,
+	}
+	return o.client, nil
+}
+
+// buildTransport clones http.DefaultTransport and applies the TLS and proxy options on it.
+func (o *HTTPOptions) buildTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	// set tls config
+	tlsConfig := &tls.Config{InsecureSkipVerify: o.InsecureSkipVerify}
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read ca file error: %s", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in ca file: %s", o.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if o.CertFile != "" || o.KeyFile != "" {
+		if o.CertFile == "" || o.KeyFile == "" {
+			return nil, errors.New("cert file and key file must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate error: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	// set proxy
+	if o.ProxyURL != "" {
+		proxy, err := url.Parse(o.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse proxy url error: %s", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return transport, nil
+}
@@ -1,13 +1,56 @@
 package apiauth
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 )
 
+const (
+	resourcesCacheAPI = "/common/resources/cache/"
+)
+
 type JmsAPI interface {
 	MakeRequest(method, endpoint string, body interface{}) (*http.Request, error)
 	DoRequest(req *http.Request, result interface{}) error
 	SetQuery(req *http.Request, v url.Values) *http.Request
 	GetEndpoint() string
 }
+
+// ContextRequester is implemented by the JmsAPI configurations able to build a request bound to a context.
+// JmsAPIConfig, JmsAKConfig and JmsSDKConfig implement it. It is kept apart from JmsAPI so that the existing
+// implementations and mocks of JmsAPI keep satisfying it.
+type ContextRequester interface {
+	MakeRequestWithContext(ctx context.Context, method, endpoint string, body interface{}) (*http.Request, error)
+}
+
+// MakeRequestWithContext builds a request with api bound to ctx. An api that does not implement
+// ContextRequester builds it with MakeRequest, then the request is bound to ctx with WithContext.
+func MakeRequestWithContext(ctx context.Context, api JmsAPI, method, endpoint string, body interface{}) (*http.Request, error) {
+	if c, ok := api.(ContextRequester); ok {
+		return c.MakeRequestWithContext(ctx, method, endpoint, body)
+	}
+	req, err := api.MakeRequest(method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	return req.WithContext(ctx), nil
+}
+
+// RawRequester is implemented by the JmsAPI configurations able to return a response with its body unread,
+// which is used to download files. JmsAPIConfig, JmsAKConfig and JmsSDKConfig implement it. It is kept
+// apart from JmsAPI so that the existing implementations and mocks of JmsAPI keep satisfying it.
+type RawRequester interface {
+	DoRawRequest(req *http.Request) (*http.Response, error)
+}
+
+// DoRawRequest performs req with api when it implements RawRequester and returns the response with its
+// body unread. The caller must close the response body.
+func DoRawRequest(api JmsAPI, req *http.Request) (*http.Response, error) {
+	raw, ok := api.(RawRequester)
+	if !ok {
+		return nil, fmt.Errorf("%T does not support raw requests", api)
+	}
+	return raw.DoRawRequest(req)
+}
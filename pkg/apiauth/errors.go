@@ -0,0 +1,159 @@
+package apiauth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bytedance/sonic"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned by DoRequest when the server answers with a status code outside the 200-399 range.
+// Use errors.As to retrieve it, or one of the IsXxx helpers to test for a common status.
+//
+// Properties:
+//
+//	StatusCode: The HTTP status code of the response.
+//	Method: The HTTP method of the failed request.
+//	URL: The URL of the failed request.
+//	RequestID: The request id reported by the server in the X-Request-Id header, if any.
+//	Code: The error code of the JumpServer error body, if any.
+//	Detail: The human readable message of the JumpServer error body ('detail', 'error' or 'msg').
+//	Fields: The per-field validation messages of the JumpServer error body, keyed by field name.
+//	Body: The raw response body.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	RequestID  string
+	Code       string
+	Detail     string
+	Fields     map[string][]string
+	Body       []byte
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Detail
+	if msg == "" && len(e.Fields) > 0 {
+		keys := make([]string, 0, len(e.Fields))
+		for k := range e.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("%s: %s", k, strings.Join(e.Fields[k], " ")))
+		}
+		msg = strings.Join(parts, "; ")
+	}
+	if msg == "" {
+		msg = string(e.Body)
+	}
+	return fmt.Sprintf("server response code is not ok, %s %s, code:%d, content:%s", e.Method, e.URL, e.StatusCode, msg)
+}
+
+// NewAPIError builds an APIError from a failed response and its already read body. It is used by the
+// resources sending requests outside of the JmsAPI, such as the downloads from an object storage.
+// The body is decoded as a JumpServer error, a body that is not a JSON object is kept only in Body.
+func NewAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Body:       body,
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.URL = resp.Request.URL.String()
+	}
+
+	// decode jumpserver error body
+	content := make(map[string]interface{})
+	if err := sonic.Unmarshal(body, &content); err != nil {
+		return e
+	}
+	for k, v := range content {
+		switch k {
+		case "detail", "error", "msg":
+			if s, ok := v.(string); ok && e.Detail == "" {
+				e.Detail = s
+				continue
+			}
+		case "code":
+			if s, ok := v.(string); ok {
+				e.Code = s
+				continue
+			}
+		}
+		if msgs := fieldMessages(v); len(msgs) > 0 {
+			if e.Fields == nil {
+				e.Fields = make(map[string][]string)
+			}
+			e.Fields[k] = msgs
+		}
+	}
+	return e
+}
+
+// fieldMessages flattens a validation message value, which is either a string or a list of strings.
+func fieldMessages(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		msgs := make([]string, 0, len(val))
+		for _, item := range val {
+			msgs = append(msgs, fieldMessages(item)...)
+		}
+		return msgs
+	case map[string]interface{}:
+		msgs := make([]string, 0, len(val))
+		for k, item := range val {
+			for _, m := range fieldMessages(item) {
+				msgs = append(msgs, fmt.Sprintf("%s: %s", k, m))
+			}
+		}
+		sort.Strings(msgs)
+		return msgs
+	default:
+		return nil
+	}
+}
+
+// hasStatus reports whether err is an APIError with the given status code.
+func hasStatus(err error, code int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError with status 401.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsPermissionDenied reports whether err is an APIError with status 403.
+func IsPermissionDenied(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsRateLimited reports whether err is an APIError with status 429.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsBadRequest reports whether err is an APIError with status 400, which JumpServer uses for validation errors.
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// IsServerError reports whether err is an APIError with a 5xx status.
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
@@ -0,0 +1,69 @@
+package apiauth
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		detail string
+		code   string
+		fields map[string][]string
+		is     func(error) bool
+	}{
+		{
+			name: "detail", status: http.StatusNotFound,
+			body:   `{"detail":"Not found."}`,
+			detail: "Not found.", is: IsNotFound,
+		},
+		{
+			name: "code and error", status: http.StatusForbidden,
+			body:   `{"code":"permission_denied","error":"denied"}`,
+			detail: "denied", code: "permission_denied", is: IsPermissionDenied,
+		},
+		{
+			name: "validation fields", status: http.StatusBadRequest,
+			body:   `{"name":["This field is required."],"accounts":{"0":"invalid"}}`,
+			fields: map[string][]string{"name": {"This field is required."}, "accounts": {"0: invalid"}},
+		},
+		{
+			name: "not json", status: http.StatusBadGateway,
+			body: `<html>bad gateway</html>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _ := url.Parse("http://jms.example.com/api/v1/users/users/")
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{"X-Request-Id": {"req-1"}},
+				Request:    &http.Request{Method: http.MethodGet, URL: u},
+			}
+			err := NewAPIError(resp, []byte(tt.body))
+			if err.StatusCode != tt.status || err.Method != http.MethodGet || err.URL != u.String() || err.RequestID != "req-1" {
+				t.Errorf("NewAPIError() = %+v, response fields not copied", err)
+			}
+			if err.Detail != tt.detail {
+				t.Errorf("NewAPIError() Detail = %q, want %q", err.Detail, tt.detail)
+			}
+			if err.Code != tt.code {
+				t.Errorf("NewAPIError() Code = %q, want %q", err.Code, tt.code)
+			}
+			if !reflect.DeepEqual(err.Fields, tt.fields) {
+				t.Errorf("NewAPIError() Fields = %v, want %v", err.Fields, tt.fields)
+			}
+			if string(err.Body) != tt.body {
+				t.Errorf("NewAPIError() Body = %q, want %q", err.Body, tt.body)
+			}
+			if tt.is != nil && !tt.is(err) {
+				t.Errorf("NewAPIError() = %v, status helper reports false", err)
+			}
+		})
+	}
+}
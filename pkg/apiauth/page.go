@@ -0,0 +1,228 @@
+package apiauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/bytedance/sonic"
+	"github.com/google/go-querystring/query"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size used by Pager and Iterator when the query does not set a limit.
+const DefaultPageSize = 100
+
+// ListRep is the paginated response shared by every List method.
+// It holds the total count of items, the URL of the next page, the URL of the previous page
+// and the items of the current page.
+//
+// When the server answers with a bare list, because no limit was requested, Count is set to the
+// number of items and Next and Previous are nil.
+type ListRep[T any] struct {
+	Count    int         `json:"count"`
+	Next     interface{} `json:"next"`
+	Previous interface{} `json:"previous"`
+	Results  []T         `json:"results"`
+}
+
+// List sends a GET request to endpoint with the query v and decodes the response into a ListRep.
+// A zero or empty 'limit' is removed from the query together with 'offset', so the server returns
+// every item without pagination. v itself is left unchanged.
+func List[T any](ctx context.Context, api JmsAPI, endpoint string, v url.Values) (*ListRep[T], error) {
+	// make request
+	req, err := MakeRequestWithContext(ctx, api, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// set query params
+	if v != nil {
+		v = cloneValues(v)
+		if limit := v.Get("limit"); limit == "" || limit == "0" {
+			v.Del("limit")
+			v.Del("offset")
+		}
+		req = api.SetQuery(req, v)
+	}
+
+	// do request
+	var raw json.RawMessage
+	if err = api.DoRequest(req, &raw); err != nil {
+		return nil, err
+	}
+
+	// decode paginated or bare list
+	data := &ListRep[T]{}
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err = sonic.Unmarshal(trimmed, &data.Results); err != nil {
+			return nil, err
+		}
+		data.Count = len(data.Results)
+		return data, nil
+	}
+	err = sonic.Unmarshal(raw, data)
+	return data, err
+}
+
+// Pager walks the pages of a list endpoint using 'limit' and 'offset'.
+// It is created with NewPager and each call to Next fetches the following page.
+type Pager[T any] struct {
+	api      JmsAPI
+	endpoint string
+	query    url.Values
+	limit    int
+	offset   int
+	done     bool
+	err      error
+}
+
+// NewPager returns a Pager over endpoint with the query v.
+// The 'limit' of v is used as page size, DefaultPageSize when it is not set,
+// and the 'offset' of v as the starting offset. v is copied, so it can be reused once the Pager is created.
+func NewPager[T any](api JmsAPI, endpoint string, v url.Values) *Pager[T] {
+	v = cloneValues(v)
+	limit, _ := strconv.Atoi(v.Get("limit"))
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	offset, _ := strconv.Atoi(v.Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+	return &Pager[T]{
+		api:      api,
+		endpoint: endpoint,
+		query:    v,
+		limit:    limit,
+		offset:   offset,
+	}
+}
+
+// NewQueryPager returns a Pager over endpoint with the query encoded from filter, a struct pointer of
+// 'url' tagged fields, see NewPager for the paging options. An error encoding filter is returned by the
+// first call to Next.
+func NewQueryPager[T any](api JmsAPI, endpoint string, filter interface{}) *Pager[T] {
+	v, err := query.Values(filter)
+	p := NewPager[T](api, endpoint, v)
+	p.err = err
+	return p
+}
+
+// HasNext reports whether there may be another page to fetch.
+func (p *Pager[T]) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page. Once the last page has been returned HasNext reports false
+// and Next returns an empty page.
+func (p *Pager[T]) Next(ctx context.Context) (*ListRep[T], error) {
+	if p.done {
+		return &ListRep[T]{}, nil
+	}
+	if p.err != nil {
+		p.done = true
+		return nil, p.err
+	}
+
+	// copy query with current page
+	v := cloneValues(p.query)
+	v.Set("limit", strconv.Itoa(p.limit))
+	v.Set("offset", strconv.Itoa(p.offset))
+
+	page, err := List[T](ctx, p.api, p.endpoint, v)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if exhausted, a bare list means the server does not paginate. A short page is not the end,
+	// the server caps the page size and may return fewer items than the limit asked for.
+	p.offset += len(page.Results)
+	if len(page.Results) == 0 || page.Next == nil || p.offset >= page.Count {
+		p.done = true
+	}
+	return page, nil
+}
+
+// Iterator walks every item of a list endpoint, fetching the pages on demand.
+//
+// Usage:
+//
+//	it := apiauth.NewIterator[T](api, endpoint, v)
+//	for it.Next(ctx) {
+//		item := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator[T any] struct {
+	pager *Pager[T]
+	items []T
+	index int
+	err   error
+}
+
+// NewIterator returns an Iterator over endpoint with the query v, see NewPager for the paging options.
+func NewIterator[T any](api JmsAPI, endpoint string, v url.Values) *Iterator[T] {
+	return &Iterator[T]{pager: NewPager[T](api, endpoint, v), index: -1}
+}
+
+// NewQueryIterator returns an Iterator over endpoint with the query encoded from filter, see NewQueryPager.
+// An error encoding filter stops the iteration and is returned by Err.
+func NewQueryIterator[T any](api JmsAPI, endpoint string, filter interface{}) *Iterator[T] {
+	return &Iterator[T]{pager: NewQueryPager[T](api, endpoint, filter), index: -1}
+}
+
+// Next advances to the next item, fetching the next page when needed.
+// It returns false when every item has been visited or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.items) {
+		if !it.pager.HasNext() {
+			return false
+		}
+		page, err := it.pager.Next(ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items, it.index = page.Results, 0
+	}
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.items[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// ListAll fetches every page of endpoint with the query v and returns all the items.
+func ListAll[T any](ctx context.Context, api JmsAPI, endpoint string, v url.Values) ([]T, error) {
+	pager := NewPager[T](api, endpoint, v)
+	items := make([]T, 0)
+	for pager.HasNext() {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Results...)
+	}
+	return items, nil
+}
+
+// cloneValues returns a deep copy of v, never nil.
+func cloneValues(v url.Values) url.Values {
+	c := make(url.Values, len(v))
+	for k, vs := range v {
+		c[k] = append([]string(nil), vs...)
+	}
+	return c
+}
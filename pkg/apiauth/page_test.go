@@ -0,0 +1,158 @@
+package apiauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// listServer serves total items paginated by limit and offset, never returning more than maxPage items.
+// When nullNext is set the next link is always null, when bare is set the items are returned as a bare list.
+func listServer(total, maxPage int, nullNext, bare bool, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		items := make([]int, total)
+		for i := range items {
+			items[i] = i
+		}
+		if bare {
+			json.NewEncoder(w).Encode(items)
+			return
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if limit > maxPage {
+			limit = maxPage
+		}
+		end := offset + limit
+		if end > total {
+			end = total
+		}
+		if offset > total {
+			offset = total
+		}
+		var next interface{}
+		if end < total && !nullNext {
+			next = "next"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"count": total, "next": next, "previous": nil, "results": items[offset:end],
+		})
+	}))
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		maxPage  int
+		limit    string
+		nullNext bool
+		bare     bool
+		want     int
+		requests int
+	}{
+		{name: "full pages", total: 7, maxPage: 100, limit: "3", want: 7, requests: 3},
+		{name: "exact pages", total: 6, maxPage: 100, limit: "3", want: 6, requests: 2},
+		{name: "short pages capped by server", total: 7, maxPage: 2, limit: "5", want: 7, requests: 4},
+		{name: "null next", total: 7, maxPage: 100, limit: "3", nullNext: true, want: 3, requests: 1},
+		{name: "empty", total: 0, maxPage: 100, limit: "3", want: 0, requests: 1},
+		{name: "bare list", total: 5, maxPage: 100, bare: true, want: 5, requests: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := listServer(tt.total, tt.maxPage, tt.nullNext, tt.bare, &requests)
+			defer srv.Close()
+
+			api := &JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+			v := url.Values{}
+			if tt.limit != "" {
+				v.Set("limit", tt.limit)
+			}
+			items, err := ListAll[int](context.Background(), api, srv.URL, v)
+			if err != nil {
+				t.Fatalf("ListAll() error = %v", err)
+			}
+			if len(items) != tt.want {
+				t.Errorf("ListAll() returned %d items, want %d", len(items), tt.want)
+			}
+			for i, item := range items {
+				if item != i {
+					t.Fatalf("ListAll() item %d = %d, want %d", i, item, i)
+				}
+			}
+			if requests != tt.requests {
+				t.Errorf("ListAll() sent %d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
+
+func TestListKeepsQuery(t *testing.T) {
+	requests := 0
+	srv := listServer(5, 100, false, false, &requests)
+	defer srv.Close()
+	api := &JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+
+	v := url.Values{"limit": {"0"}, "offset": {"3"}, "search": {"demo"}}
+	if _, err := List[int](context.Background(), api, srv.URL, v); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := url.Values{"limit": {"0"}, "offset": {"3"}, "search": {"demo"}}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("List() changed the query to %v, want %v", v, want)
+	}
+
+	v = url.Values{"limit": {"2"}}
+	pager := NewPager[int](api, srv.URL, v)
+	v.Set("offset", "4")
+	page, err := pager.Next(context.Background())
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if !reflect.DeepEqual(page.Results, []int{0, 1}) {
+		t.Errorf("Next() = %v, want the first page, the query must be copied by NewPager", page.Results)
+	}
+	if v.Get("offset") != "4" || v.Get("limit") != "2" {
+		t.Errorf("Next() changed the query to %v", v)
+	}
+}
+
+func TestQueryIterator(t *testing.T) {
+	requests := 0
+	srv := listServer(5, 100, false, false, &requests)
+	defer srv.Close()
+	api := &JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+
+	filter := &struct {
+		Limit int `url:"limit,omitempty"`
+	}{Limit: 2}
+	it := NewQueryIterator[int](api, srv.URL, filter)
+	count := 0
+	for it.Next(context.Background()) {
+		if it.Value() != count {
+			t.Errorf("Value() = %d, want %d", it.Value(), count)
+		}
+		count++
+	}
+	if err := it.Err(); err != nil || count != 5 || requests != 3 {
+		t.Errorf("iterated %d items in %d requests with error %v, want 5 items in 3 requests", count, requests, err)
+	}
+
+	// a filter that can not be encoded is reported without any request
+	requests = 0
+	it = NewQueryIterator[int](api, srv.URL, 42)
+	if it.Next(context.Background()) || it.Err() == nil || requests != 0 {
+		t.Errorf("Next() of an invalid filter sent %d requests with error %v, want an error", requests, it.Err())
+	}
+	pager := NewQueryPager[int](api, srv.URL, 42)
+	if _, err := pager.Next(context.Background()); err == nil || pager.HasNext() {
+		t.Errorf("Next() of an invalid filter error = %v, HasNext() = %v, want an error and no more pages", err, pager.HasNext())
+	}
+}
@@ -0,0 +1,234 @@
+package apiauth
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+)
+
+// defaultRetryStatus is the list of status codes retried when RetryPolicy.RetryStatus is empty.
+var defaultRetryStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy configures how failed requests are retried. The zero value disables retries.
+//
+// Properties:
+//
+//	MaxAttempts: The total number of attempts including the first one. Values below 2 disable retries.
+//	InitialBackoff: The wait before the first retry, doubled on each following retry. Defaults to 500ms.
+//	MaxBackoff: The upper bound of the computed wait and of the Retry-After delay. Defaults to 30s.
+//	RetryStatus: The response status codes that are retried. Defaults to 429, 502, 503 and 504.
+//	RetryNonIdempotent: Also retry POST and PATCH requests, which are not retried by default.
+//
+// Network errors and the listed status codes are retried. The wait is randomized between half and
+// all of the computed backoff, and a Retry-After header sent by the server takes precedence over it,
+// capped at MaxBackoff so that a server can not park the client for longer.
+type RetryPolicy struct {
+	MaxAttempts        int           `json:"max_attempts"`
+	InitialBackoff     time.Duration `json:"initial_backoff"`
+	MaxBackoff         time.Duration `json:"max_backoff"`
+	RetryStatus        []int         `json:"retry_status"`
+	RetryNonIdempotent bool          `json:"retry_non_idempotent"`
+}
+
+// attempts returns how many times a request with the given method may be sent.
+func (p *RetryPolicy) attempts(method string) int {
+	if p.MaxAttempts < 2 {
+		return 1
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return p.MaxAttempts
+	}
+	if p.RetryNonIdempotent {
+		return p.MaxAttempts
+	}
+	return 1
+}
+
+// retryable reports whether a response with the given status code should be retried.
+func (p *RetryPolicy) retryable(code int) bool {
+	status := p.RetryStatus
+	if len(status) == 0 {
+		status = defaultRetryStatus
+	}
+	for _, s := range status {
+		if s == code {
+			return true
+		}
+	}
+	return false
+}
+
+// maxBackoff returns the upper bound of any wait between two attempts.
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff <= 0 {
+		return defaultMaxBackoff
+	}
+	return p.MaxBackoff
+}
+
+// backoff returns the randomized wait before the given retry, starting at 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	initial, max := p.InitialBackoff, p.maxBackoff()
+	if initial <= 0 {
+		initial = defaultInitialBackoff
+	}
+	d := initial
+	for i := 1; i < retry && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses the Retry-After header of resp, given either in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// send performs req with the shared client and the retry policy of the options, and returns the
+// response together with its fully read body.
+func (o *HTTPOptions) send(req *http.Request, sign func(*http.Request) error) (*http.Response, []byte, error) {
+	resp, err := o.roundTrip(req, sign)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// read response body
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// roundTrip performs req with the shared client and the retry policy of the options, and returns the
+// response of the last attempt with its body left open for the caller to read and close.
+//
+// When sign is not nil it is called before every attempt after the Date header has been removed,
+// so signed requests always carry a fresh date. The request body is rewound with req.GetBody between
+// attempts, a request whose body cannot be rewound is sent only once.
+// When the request context is done, its error is returned as is.
+func (o *HTTPOptions) roundTrip(req *http.Request, sign func(*http.Request) error) (*http.Response, error) {
+	// get client
+	client, err := o.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := o.Retry.attempts(req.Method)
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		// rewind body
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		// sign request
+		if sign != nil {
+			req.Header.Del("Date")
+			if err := sign(req); err != nil {
+				return nil, err
+			}
+		}
+
+		// do request
+		resp, err := client.Do(req)
+		if err != nil {
+			if ctxErr := req.Context().Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			if attempt >= attempts {
+				return nil, err
+			}
+			if err := o.wait(req, o.Retry.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// check if response should be retried
+		if attempt >= attempts || !o.Retry.retryable(resp.StatusCode) {
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		d, ok := retryAfter(resp)
+		if !ok {
+			d = o.Retry.backoff(attempt)
+		} else if max := o.Retry.maxBackoff(); d > max {
+			d = max
+		}
+		if err := o.wait(req, d); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// stream performs req like send but leaves the body of a successful response open for the caller,
+// which must close it. A response outside the 200-399 range is read and returned as an *APIError.
+func (o *HTTPOptions) stream(req *http.Request, sign func(*http.Request) error) (*http.Response, error) {
+	resp, err := o.roundTrip(req, sign)
+	if err != nil {
+		return nil, err
+	}
+
+	// check response status code
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, NewAPIError(resp, body)
+	}
+	return resp, nil
+}
+
+// wait blocks for d or until the request context is done.
+func (o *HTTPOptions) wait(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
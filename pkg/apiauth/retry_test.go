@@ -0,0 +1,78 @@
+package apiauth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		policy     RetryPolicy
+		failures   int
+		retryAfter string
+		wantStatus int
+		requests   int
+	}{
+		{name: "disabled", method: http.MethodGet, failures: 1, wantStatus: http.StatusServiceUnavailable, requests: 1},
+		{name: "get retried", method: http.MethodGet, policy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}, failures: 2, requests: 3},
+		{name: "get exhausted", method: http.MethodGet, policy: RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}, failures: 5, wantStatus: http.StatusServiceUnavailable, requests: 2},
+		{name: "put retried with body", method: http.MethodPut, policy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}, failures: 1, requests: 2},
+		{name: "post not retried", method: http.MethodPost, policy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}, failures: 1, wantStatus: http.StatusServiceUnavailable, requests: 1},
+		{name: "post retried when allowed", method: http.MethodPost, policy: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryNonIdempotent: true}, failures: 1, requests: 2},
+		{name: "retry after capped", method: http.MethodGet, policy: RetryPolicy{MaxAttempts: 2, MaxBackoff: time.Millisecond}, failures: 1, retryAfter: "3600", requests: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if body, _ := io.ReadAll(r.Body); r.Method != http.MethodGet && string(body) != `{"name":"demo"}` {
+					t.Errorf("attempt %d sent body %q", requests, body)
+				}
+				if requests <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(http.StatusServiceUnavailable)
+					w.Write([]byte(`{"detail":"unavailable"}`))
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			api := &JmsAPIConfig{Endpoints: srv.URL, Token: "token", HTTPOptions: HTTPOptions{Retry: tt.policy}}
+			var data interface{}
+			if tt.method != http.MethodGet {
+				data = map[string]string{"name": "demo"}
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			req, err := api.MakeRequestWithContext(ctx, tt.method, srv.URL, data)
+			if err != nil {
+				t.Fatalf("MakeRequestWithContext() error = %v", err)
+			}
+			err = api.DoRequest(req, nil)
+
+			var apiErr *APIError
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Errorf("DoRequest() error = %v, want nil", err)
+			case tt.wantStatus != 0 && !errors.As(err, &apiErr):
+				t.Errorf("DoRequest() error = %v, want an *APIError", err)
+			case tt.wantStatus != 0 && apiErr.StatusCode != tt.wantStatus:
+				t.Errorf("DoRequest() status = %d, want %d", apiErr.StatusCode, tt.wantStatus)
+			}
+			if requests != tt.requests {
+				t.Errorf("DoRequest() sent %d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
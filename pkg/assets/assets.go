@@ -1,6 +1,7 @@
 package assets
 
 import (
+	"context"
 	"fmt"
 	"github.com/MScuti/gojms/pkg/apiauth"
 	"github.com/MScuti/gojms/pkg/utils"
@@ -33,12 +34,9 @@ type AssetDetailRep struct {
 		Id   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"nodes"`
-	Labels    []interface{} `json:"labels"`
-	Protocols []struct {
-		Name string `json:"name"`
-		Port int    `json:"port"`
-	} `json:"protocols"`
-	NodesDisplay []string `json:"nodes_display"`
+	Labels       []interface{} `json:"labels"`
+	Protocols    []Protocol    `json:"protocols"`
+	NodesDisplay []string      `json:"nodes_display"`
 	Accounts     []struct {
 		Id         string `json:"id"`
 		Name       string `json:"name"`
@@ -127,9 +125,9 @@ type AssetDetailRep struct {
 	DateCreated  string      `json:"date_created"`
 }
 
-// AssetListRep is a type that represents a list of AssetDetailRep objects.
-// It's basically a slice of AssetDetailRep struct instances which represent multiple asset details.
-type AssetListRep []AssetDetailRep
+// AssetListRep is the paginated response of the assets list endpoint.
+// Results holds the AssetDetailRep of the current page.
+type AssetListRep = apiauth.ListRep[AssetDetailRep]
 
 // Get is a method on the Assets struct.
 // It takes a string id as a parameter and retrieves the asset details
@@ -140,6 +138,11 @@ type AssetListRep []AssetDetailRep
 // If the retrieval and unmarshalling are successful, it returns the AssetDetailRep
 // object along with a nil error. Otherwise, it returns nil and the associated error.
 func (s *Assets) Get(id string) (*AssetDetailRep, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but issues the request with ctx.
+func (s *Assets) GetWithContext(ctx context.Context, id string) (*AssetDetailRep, error) {
 	// check id
 	if id == "" {
 		return nil, fmt.Errorf("session id can not empty")
@@ -150,7 +153,7 @@ func (s *Assets) Get(id string) (*AssetDetailRep, error) {
 	endpoint = fmt.Sprintf(endpoint, id)
 
 	// make request
-	req, err := s.API.MakeRequest(http.MethodGet, endpoint, nil)
+	req, err := apiauth.MakeRequestWithContext(ctx, s.API, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -170,26 +173,52 @@ func (s *Assets) Get(id string) (*AssetDetailRep, error) {
 // If the operations are successful, it returns a pointer to the AssetListRep object and a nil error.
 // If there's an error during these operations, it returns nil and the relevant error.
 func (s *Assets) List(filter *AssetFilter) (*AssetListRep, error) {
+	return s.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List but issues the request with ctx.
+func (s *Assets) ListWithContext(ctx context.Context, filter *AssetFilter) (*AssetListRep, error) {
 	// combine api endpoint
 	endpoint := utils.CombineURL(s.API.GetEndpoint(), assetsListAPI)
 
-	// make request
-	req, err := s.API.MakeRequest(http.MethodGet, endpoint, nil)
+	// set query params
+	v, err := query.Values(filter)
 	if err != nil {
 		return nil, err
 	}
 
+	// do request
+	return apiauth.List[AssetDetailRep](ctx, s.API, endpoint, v)
+}
+
+// ListAll fetches every page of the assets matching filter and returns all of them.
+// The Limit of filter is used as page size, apiauth.DefaultPageSize when it is not set.
+func (s *Assets) ListAll(filter *AssetFilter) ([]AssetDetailRep, error) {
+	return s.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (s *Assets) ListAllWithContext(ctx context.Context, filter *AssetFilter) ([]AssetDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(s.API.GetEndpoint(), assetsListAPI)
+
 	// set query params
-	if filter != nil {
-		v, err := query.Values(filter)
-		if err != nil {
-			return nil, err
-		}
-		req = s.API.SetQuery(req, v)
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
 	}
 
 	// do request
-	data := &AssetListRep{}
-	err = s.API.DoRequest(req, data)
-	return data, err
+	return apiauth.ListAll[AssetDetailRep](ctx, s.API, endpoint, v)
+}
+
+// Pages returns a pager over the assets matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (s *Assets) Pages(filter *AssetFilter) *apiauth.Pager[AssetDetailRep] {
+	return apiauth.NewQueryPager[AssetDetailRep](s.API, utils.CombineURL(s.API.GetEndpoint(), assetsListAPI), filter)
+}
+
+// Iter returns an iterator over every asset matching filter, fetching the pages on demand.
+func (s *Assets) Iter(filter *AssetFilter) *apiauth.Iterator[AssetDetailRep] {
+	return apiauth.NewQueryIterator[AssetDetailRep](s.API, utils.CombineURL(s.API.GetEndpoint(), assetsListAPI), filter)
 }
@@ -0,0 +1,236 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"net/http"
+)
+
+// Protocol is a protocol enabled on an asset together with its port, for example ssh on 22.
+type Protocol struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+}
+
+// AssetReq holds the fields shared by every asset category when creating or updating an asset.
+// Platform is the id of the asset platform, Domain the id of the gateway domain and Nodes the ids
+// of the nodes the asset is placed in. IsActive is left to the server default when nil, a new asset is active.
+type AssetReq struct {
+	Name      string     `json:"name"`
+	Address   string     `json:"address"`
+	Platform  int        `json:"platform"`
+	Domain    string     `json:"domain,omitempty"`
+	Nodes     []string   `json:"nodes,omitempty"`
+	Protocols []Protocol `json:"protocols,omitempty"`
+	Labels    []string   `json:"labels,omitempty"`
+	IsActive  *bool      `json:"is_active,omitempty"`
+	Comment   string     `json:"comment,omitempty"`
+}
+
+// HostReq is the request body used to create or update a host.
+type HostReq = AssetReq
+
+// DeviceReq is the request body used to create or update a network device.
+type DeviceReq = AssetReq
+
+// CloudReq is the request body used to create or update a cloud asset, such as a kubernetes cluster.
+type CloudReq = AssetReq
+
+// DatabaseReq is the request body used to create or update a database.
+// The certificates are PEM contents used when UseSSL is set.
+type DatabaseReq struct {
+	AssetReq
+	DBName           string `json:"db_name"`
+	UseSSL           bool   `json:"use_ssl"`
+	CaCert           string `json:"ca_cert,omitempty"`
+	ClientCert       string `json:"client_cert,omitempty"`
+	ClientKey        string `json:"client_key,omitempty"`
+	AllowInvalidCert bool   `json:"allow_invalid_cert"`
+}
+
+// WebReq is the request body used to create or update a web asset.
+// Autofill is one of 'no', 'basic' or 'script'. The selectors are used by the 'basic' autofill
+// and Script by the 'script' autofill.
+type WebReq struct {
+	AssetReq
+	Autofill         string        `json:"autofill"`
+	UsernameSelector string        `json:"username_selector,omitempty"`
+	PasswordSelector string        `json:"password_selector,omitempty"`
+	SubmitSelector   string        `json:"submit_selector,omitempty"`
+	Script           []interface{} `json:"script,omitempty"`
+}
+
+// AssetPatchReq is the request body used to partially update an asset of any category.
+// Only the non nil fields are sent.
+type AssetPatchReq struct {
+	Name      *string     `json:"name,omitempty"`
+	Address   *string     `json:"address,omitempty"`
+	Domain    *string     `json:"domain,omitempty"`
+	Nodes     *[]string   `json:"nodes,omitempty"`
+	Protocols *[]Protocol `json:"protocols,omitempty"`
+	Labels    *[]string   `json:"labels,omitempty"`
+	IsActive  *bool       `json:"is_active,omitempty"`
+	Comment   *string     `json:"comment,omitempty"`
+}
+
+// CreateHost creates a host from data and returns the created asset.
+func (s *Assets) CreateHost(data *HostReq) (*AssetDetailRep, error) {
+	return s.CreateHostWithContext(context.Background(), data)
+}
+
+// CreateHostWithContext is like CreateHost but issues the request with ctx.
+func (s *Assets) CreateHostWithContext(ctx context.Context, data *HostReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPost, hostsListAPI, "", data)
+}
+
+// UpdateHost replaces the host identified by id with data and returns the updated asset.
+func (s *Assets) UpdateHost(id string, data *HostReq) (*AssetDetailRep, error) {
+	return s.UpdateHostWithContext(context.Background(), id, data)
+}
+
+// UpdateHostWithContext is like UpdateHost but issues the request with ctx.
+func (s *Assets) UpdateHostWithContext(ctx context.Context, id string, data *HostReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPut, hostsListAPI, id, data)
+}
+
+// CreateDatabase creates a database from data and returns the created asset.
+func (s *Assets) CreateDatabase(data *DatabaseReq) (*AssetDetailRep, error) {
+	return s.CreateDatabaseWithContext(context.Background(), data)
+}
+
+// CreateDatabaseWithContext is like CreateDatabase but issues the request with ctx.
+func (s *Assets) CreateDatabaseWithContext(ctx context.Context, data *DatabaseReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPost, databasesListAPI, "", data)
+}
+
+// UpdateDatabase replaces the database identified by id with data and returns the updated asset.
+func (s *Assets) UpdateDatabase(id string, data *DatabaseReq) (*AssetDetailRep, error) {
+	return s.UpdateDatabaseWithContext(context.Background(), id, data)
+}
+
+// UpdateDatabaseWithContext is like UpdateDatabase but issues the request with ctx.
+func (s *Assets) UpdateDatabaseWithContext(ctx context.Context, id string, data *DatabaseReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPut, databasesListAPI, id, data)
+}
+
+// CreateDevice creates a network device from data and returns the created asset.
+func (s *Assets) CreateDevice(data *DeviceReq) (*AssetDetailRep, error) {
+	return s.CreateDeviceWithContext(context.Background(), data)
+}
+
+// CreateDeviceWithContext is like CreateDevice but issues the request with ctx.
+func (s *Assets) CreateDeviceWithContext(ctx context.Context, data *DeviceReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPost, devicesListAPI, "", data)
+}
+
+// UpdateDevice replaces the network device identified by id with data and returns the updated asset.
+func (s *Assets) UpdateDevice(id string, data *DeviceReq) (*AssetDetailRep, error) {
+	return s.UpdateDeviceWithContext(context.Background(), id, data)
+}
+
+// UpdateDeviceWithContext is like UpdateDevice but issues the request with ctx.
+func (s *Assets) UpdateDeviceWithContext(ctx context.Context, id string, data *DeviceReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPut, devicesListAPI, id, data)
+}
+
+// CreateWeb creates a web asset from data and returns the created asset.
+func (s *Assets) CreateWeb(data *WebReq) (*AssetDetailRep, error) {
+	return s.CreateWebWithContext(context.Background(), data)
+}
+
+// CreateWebWithContext is like CreateWeb but issues the request with ctx.
+func (s *Assets) CreateWebWithContext(ctx context.Context, data *WebReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPost, websListAPI, "", data)
+}
+
+// UpdateWeb replaces the web asset identified by id with data and returns the updated asset.
+func (s *Assets) UpdateWeb(id string, data *WebReq) (*AssetDetailRep, error) {
+	return s.UpdateWebWithContext(context.Background(), id, data)
+}
+
+// UpdateWebWithContext is like UpdateWeb but issues the request with ctx.
+func (s *Assets) UpdateWebWithContext(ctx context.Context, id string, data *WebReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPut, websListAPI, id, data)
+}
+
+// CreateCloud creates a cloud asset from data and returns the created asset.
+func (s *Assets) CreateCloud(data *CloudReq) (*AssetDetailRep, error) {
+	return s.CreateCloudWithContext(context.Background(), data)
+}
+
+// CreateCloudWithContext is like CreateCloud but issues the request with ctx.
+func (s *Assets) CreateCloudWithContext(ctx context.Context, data *CloudReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPost, cloudsListAPI, "", data)
+}
+
+// UpdateCloud replaces the cloud asset identified by id with data and returns the updated asset.
+func (s *Assets) UpdateCloud(id string, data *CloudReq) (*AssetDetailRep, error) {
+	return s.UpdateCloudWithContext(context.Background(), id, data)
+}
+
+// UpdateCloudWithContext is like UpdateCloud but issues the request with ctx.
+func (s *Assets) UpdateCloudWithContext(ctx context.Context, id string, data *CloudReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPut, cloudsListAPI, id, data)
+}
+
+// PartialUpdate updates the non nil fields of data on the asset identified by id, whatever its category.
+func (s *Assets) PartialUpdate(id string, data *AssetPatchReq) (*AssetDetailRep, error) {
+	return s.PartialUpdateWithContext(context.Background(), id, data)
+}
+
+// PartialUpdateWithContext is like PartialUpdate but issues the request with ctx.
+func (s *Assets) PartialUpdateWithContext(ctx context.Context, id string, data *AssetPatchReq) (*AssetDetailRep, error) {
+	return s.send(ctx, http.MethodPatch, assetsListAPI, id, data)
+}
+
+// Delete deletes the asset identified by id, whatever its category.
+func (s *Assets) Delete(id string) error {
+	return s.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete but issues the request with ctx.
+func (s *Assets) DeleteWithContext(ctx context.Context, id string) error {
+	_, err := s.send(ctx, http.MethodDelete, assetsListAPI, id, nil)
+	return err
+}
+
+// BulkDelete deletes every asset identified by ids in a single request.
+func (s *Assets) BulkDelete(ids []string) error {
+	return s.BulkDeleteWithContext(context.Background(), ids)
+}
+
+// BulkDeleteWithContext is like BulkDelete but issues the request with ctx.
+func (s *Assets) BulkDeleteWithContext(ctx context.Context, ids []string) error {
+	return apiauth.DeleteResources(ctx, s.API, utils.CombineURL(s.API.GetEndpoint(), assetsListAPI), ids)
+}
+
+// send sends data with method to the list endpoint api, or to its detail endpoint when id is set,
+// and decodes the response into an AssetDetailRep. DELETE responses have no content and return nil.
+func (s *Assets) send(ctx context.Context, method, api, id string, data interface{}) (*AssetDetailRep, error) {
+	// check id
+	if id == "" && method != http.MethodPost {
+		return nil, fmt.Errorf("asset id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(s.API.GetEndpoint(), api)
+	if id != "" {
+		endpoint = utils.CombineURL(endpoint, id+"/")
+	}
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, s.API, method, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	if method == http.MethodDelete {
+		return nil, s.API.DoRequest(req, nil)
+	}
+	rep := &AssetDetailRep{}
+	err = s.API.DoRequest(req, rep)
+	return rep, err
+}
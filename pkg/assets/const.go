@@ -3,4 +3,17 @@ package assets
 const (
 	assetsGetAPI  = "/assets/assets/%s/"
 	assetsListAPI = "/assets/assets/"
+
+	hostsListAPI     = "/assets/hosts/"
+	databasesListAPI = "/assets/databases/"
+	devicesListAPI   = "/assets/devices/"
+	websListAPI      = "/assets/webs/"
+	cloudsListAPI    = "/assets/clouds/"
+
+	nodeGetAPI          = "/assets/nodes/%s/"
+	nodeListAPI         = "/assets/nodes/"
+	nodeChildrenAPI     = "/assets/nodes/%s/children/"
+	nodeAddChildrenAPI  = "/assets/nodes/%s/children/add/"
+	nodeAddAssetsAPI    = "/assets/nodes/%s/assets/add/"
+	nodeRemoveAssetsAPI = "/assets/nodes/%s/assets/remove/"
 )
@@ -23,3 +23,15 @@ type AssetFilter struct {
 	Limit                 int    `url:"limit"`
 	Offset                int    `url:"offset"`
 }
+
+// NodeFilter is a struct that represents the filtering options for querying asset nodes.
+// Key is the tree key of a node, for example '1:3', and Value its name.
+type NodeFilter struct {
+	ID     string `url:"id,omitempty"`
+	Key    string `url:"key,omitempty"`
+	Value  string `url:"value,omitempty"`
+	Search string `url:"search,omitempty"`
+	Order  string `url:"order,omitempty"`
+	Limit  int    `url:"limit,omitempty"`
+	Offset int    `url:"offset,omitempty"`
+}
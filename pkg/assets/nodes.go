@@ -0,0 +1,307 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// The Nodes struct holds the configuration for the JmsAPI.
+// It is used to manage the asset node tree.
+type Nodes struct {
+	API apiauth.JmsAPI
+}
+
+// NodeDetailRep represents a node of the asset tree.
+// Key is the position of the node in the tree, made of the keys of its ancestors joined with ':'
+// (for example '1:3:2'), Value is the node name and FullValue its full path, for example '/Default/Prod'.
+type NodeDetailRep struct {
+	Id           string `json:"id"`
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+	Name         string `json:"name"`
+	FullValue    string `json:"full_value"`
+	AssetsAmount int    `json:"assets_amount"`
+	OrgId        string `json:"org_id"`
+	OrgName      string `json:"org_name"`
+}
+
+// NodeListRep is the paginated response of the nodes list endpoint.
+type NodeListRep = apiauth.ListRep[NodeDetailRep]
+
+// NodeTree is a node of the in-memory tree built by Nodes.Tree.
+// Path is the full path of the node, made of the values of its ancestors, for example '/Default/Prod/DB'.
+type NodeTree struct {
+	Node     NodeDetailRep
+	Path     string
+	Parent   *NodeTree
+	Children []*NodeTree
+}
+
+// NodeForest is the in-memory asset node tree built by Nodes.Tree.
+// Roots holds the top level nodes, usually a single 'Default' node per organization. The nodes of
+// different organizations may share the same path, each organization has its own tree.
+type NodeForest struct {
+	Roots     []*NodeTree
+	byPath    map[string][]*NodeTree
+	byOrgPath map[orgKey]*NodeTree
+	byID      map[string]*NodeTree
+}
+
+// orgKey identifies a node path or key within an organization.
+type orgKey struct {
+	org   string
+	value string
+}
+
+// Lookup returns the node at path, for example '/Default/Prod/DB'. The leading '/' is optional.
+// It reports false when no node or when the nodes of several organizations are at path,
+// LookupOrg must be used to choose among them.
+func (f *NodeForest) Lookup(path string) (*NodeTree, bool) {
+	nodes := f.byPath["/"+strings.Trim(path, "/")]
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0], true
+}
+
+// LookupOrg returns the node at path in the organization identified by orgID.
+func (f *NodeForest) LookupOrg(orgID, path string) (*NodeTree, bool) {
+	n, ok := f.byOrgPath[orgKey{org: orgID, value: "/" + strings.Trim(path, "/")}]
+	return n, ok
+}
+
+// LookupID returns the node identified by id.
+func (f *NodeForest) LookupID(id string) (*NodeTree, bool) {
+	n, ok := f.byID[id]
+	return n, ok
+}
+
+// Get retrieves the node identified by id.
+func (n *Nodes) Get(id string) (*NodeDetailRep, error) {
+	return n.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but issues the request with ctx.
+func (n *Nodes) GetWithContext(ctx context.Context, id string) (*NodeDetailRep, error) {
+	rep := &NodeDetailRep{}
+	err := n.send(ctx, http.MethodGet, nodeGetAPI, id, nil, rep)
+	return rep, err
+}
+
+// List retrieves one page of the nodes matching filter, or every node when filter sets no Limit.
+func (n *Nodes) List(filter *NodeFilter) (*NodeListRep, error) {
+	return n.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List but issues the request with ctx.
+func (n *Nodes) ListWithContext(ctx context.Context, filter *NodeFilter) (*NodeListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(n.API.GetEndpoint(), nodeListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[NodeDetailRep](ctx, n.API, endpoint, v)
+}
+
+// ListAll fetches every page of the nodes matching filter and returns all of them.
+func (n *Nodes) ListAll(filter *NodeFilter) ([]NodeDetailRep, error) {
+	return n.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (n *Nodes) ListAllWithContext(ctx context.Context, filter *NodeFilter) ([]NodeDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(n.API.GetEndpoint(), nodeListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[NodeDetailRep](ctx, n.API, endpoint, v)
+}
+
+// Pages returns a pager over the nodes matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (n *Nodes) Pages(filter *NodeFilter) *apiauth.Pager[NodeDetailRep] {
+	return apiauth.NewQueryPager[NodeDetailRep](n.API, utils.CombineURL(n.API.GetEndpoint(), nodeListAPI), filter)
+}
+
+// Iter returns an iterator over every node matching filter, fetching the pages on demand.
+func (n *Nodes) Iter(filter *NodeFilter) *apiauth.Iterator[NodeDetailRep] {
+	return apiauth.NewQueryIterator[NodeDetailRep](n.API, utils.CombineURL(n.API.GetEndpoint(), nodeListAPI), filter)
+}
+
+// Children lists the direct children of the node identified by id.
+func (n *Nodes) Children(id string) ([]NodeDetailRep, error) {
+	return n.ChildrenWithContext(context.Background(), id)
+}
+
+// ChildrenWithContext is like Children but issues the request with ctx.
+func (n *Nodes) ChildrenWithContext(ctx context.Context, id string) ([]NodeDetailRep, error) {
+	rep := make([]NodeDetailRep, 0)
+	err := n.send(ctx, http.MethodGet, nodeChildrenAPI, id, nil, &rep)
+	return rep, err
+}
+
+// CreateChild creates a node named value under the node identified by parentID and returns it.
+func (n *Nodes) CreateChild(parentID, value string) (*NodeDetailRep, error) {
+	return n.CreateChildWithContext(context.Background(), parentID, value)
+}
+
+// CreateChildWithContext is like CreateChild but issues the request with ctx.
+func (n *Nodes) CreateChildWithContext(ctx context.Context, parentID, value string) (*NodeDetailRep, error) {
+	rep := &NodeDetailRep{}
+	err := n.send(ctx, http.MethodPost, nodeChildrenAPI, parentID, map[string]string{"value": value}, rep)
+	return rep, err
+}
+
+// Rename sets the name of the node identified by id to value and returns the updated node.
+func (n *Nodes) Rename(id, value string) (*NodeDetailRep, error) {
+	return n.RenameWithContext(context.Background(), id, value)
+}
+
+// RenameWithContext is like Rename but issues the request with ctx.
+func (n *Nodes) RenameWithContext(ctx context.Context, id, value string) (*NodeDetailRep, error) {
+	rep := &NodeDetailRep{}
+	err := n.send(ctx, http.MethodPatch, nodeGetAPI, id, map[string]string{"value": value}, rep)
+	return rep, err
+}
+
+// Move moves the nodes identified by ids, with their subtree, under the node identified by parentID.
+func (n *Nodes) Move(parentID string, ids ...string) error {
+	return n.MoveWithContext(context.Background(), parentID, ids...)
+}
+
+// MoveWithContext is like Move but issues the request with ctx.
+func (n *Nodes) MoveWithContext(ctx context.Context, parentID string, ids ...string) error {
+	return n.send(ctx, http.MethodPut, nodeAddChildrenAPI, parentID, map[string][]string{"nodes": ids}, nil)
+}
+
+// Delete deletes the node identified by id. The server refuses to delete a node holding assets or children.
+func (n *Nodes) Delete(id string) error {
+	return n.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete but issues the request with ctx.
+func (n *Nodes) DeleteWithContext(ctx context.Context, id string) error {
+	return n.send(ctx, http.MethodDelete, nodeGetAPI, id, nil, nil)
+}
+
+// AddAssets adds the assets identified by assetIDs to the node identified by id.
+func (n *Nodes) AddAssets(id string, assetIDs ...string) error {
+	return n.AddAssetsWithContext(context.Background(), id, assetIDs...)
+}
+
+// AddAssetsWithContext is like AddAssets but issues the request with ctx.
+func (n *Nodes) AddAssetsWithContext(ctx context.Context, id string, assetIDs ...string) error {
+	return n.send(ctx, http.MethodPut, nodeAddAssetsAPI, id, map[string][]string{"assets": assetIDs}, nil)
+}
+
+// RemoveAssets removes the assets identified by assetIDs from the node identified by id.
+func (n *Nodes) RemoveAssets(id string, assetIDs ...string) error {
+	return n.RemoveAssetsWithContext(context.Background(), id, assetIDs...)
+}
+
+// RemoveAssetsWithContext is like RemoveAssets but issues the request with ctx.
+func (n *Nodes) RemoveAssetsWithContext(ctx context.Context, id string, assetIDs ...string) error {
+	return n.send(ctx, http.MethodPut, nodeRemoveAssetsAPI, id, map[string][]string{"assets": assetIDs}, nil)
+}
+
+// send sends data with method to the api of the node identified by id and decodes the response into result.
+func (n *Nodes) send(ctx context.Context, method, api, id string, data, result interface{}) error {
+	// check id
+	if id == "" {
+		return fmt.Errorf("node id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(n.API.GetEndpoint(), fmt.Sprintf(api, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, n.API, method, endpoint, data)
+	if err != nil {
+		return err
+	}
+
+	// do request
+	return n.API.DoRequest(req, result)
+}
+
+// Tree fetches every node and builds the in-memory node tree, used to resolve a path to a node id.
+func (n *Nodes) Tree() (*NodeForest, error) {
+	return n.TreeWithContext(context.Background())
+}
+
+// TreeWithContext is like Tree but issues the requests with ctx.
+func (n *Nodes) TreeWithContext(ctx context.Context) (*NodeForest, error) {
+	nodes, err := n.ListAllWithContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return BuildNodeTree(nodes), nil
+}
+
+// BuildNodeTree builds the node tree from a flat list of nodes, linking each node to the node
+// of the same organization whose key is its own key without the last segment. A node whose parent
+// is missing is a root. Children are sorted by value, then by organization.
+func BuildNodeTree(nodes []NodeDetailRep) *NodeForest {
+	forest := &NodeForest{
+		byPath:    make(map[string][]*NodeTree, len(nodes)),
+		byOrgPath: make(map[orgKey]*NodeTree, len(nodes)),
+		byID:      make(map[string]*NodeTree, len(nodes)),
+	}
+
+	// index nodes by organization and key
+	byKey := make(map[orgKey]*NodeTree, len(nodes))
+	for _, node := range nodes {
+		byKey[orgKey{org: node.OrgId, value: node.Key}] = &NodeTree{Node: node}
+	}
+
+	// link children to parents
+	for key, tree := range byKey {
+		parent := ""
+		if i := strings.LastIndex(key.value, ":"); i >= 0 {
+			parent = key.value[:i]
+		}
+		if p, ok := byKey[orgKey{org: key.org, value: parent}]; ok && parent != "" {
+			tree.Parent = p
+			p.Children = append(p.Children, tree)
+		} else {
+			forest.Roots = append(forest.Roots, tree)
+		}
+	}
+
+	// compute paths from the roots
+	var walk func(trees []*NodeTree, prefix string)
+	walk = func(trees []*NodeTree, prefix string) {
+		sort.Slice(trees, func(i, j int) bool {
+			if trees[i].Node.Value != trees[j].Node.Value {
+				return trees[i].Node.Value < trees[j].Node.Value
+			}
+			return trees[i].Node.OrgId < trees[j].Node.OrgId
+		})
+		for _, tree := range trees {
+			tree.Path = prefix + "/" + tree.Node.Value
+			forest.byPath[tree.Path] = append(forest.byPath[tree.Path], tree)
+			forest.byOrgPath[orgKey{org: tree.Node.OrgId, value: tree.Path}] = tree
+			forest.byID[tree.Node.Id] = tree
+			walk(tree.Children, tree.Path)
+		}
+	}
+	walk(forest.Roots, "")
+	return forest
+}
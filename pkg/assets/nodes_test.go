@@ -0,0 +1,67 @@
+package assets
+
+import "testing"
+
+func TestBuildNodeTree(t *testing.T) {
+	nodes := []NodeDetailRep{
+		{Id: "a1", Key: "1", Value: "Default", OrgId: "org-a"},
+		{Id: "a2", Key: "1:1", Value: "Prod", OrgId: "org-a"},
+		{Id: "a3", Key: "1:1:1", Value: "DB", OrgId: "org-a"},
+		{Id: "a4", Key: "1:2", Value: "Dev", OrgId: "org-a"},
+		{Id: "b1", Key: "1", Value: "Default", OrgId: "org-b"},
+		{Id: "b2", Key: "1:1", Value: "Prod", OrgId: "org-b"},
+		{Id: "b3", Key: "1:2", Value: "Test", OrgId: "org-b"},
+	}
+	forest := BuildNodeTree(nodes)
+
+	if len(forest.Roots) != 2 {
+		t.Fatalf("BuildNodeTree() has %d roots, want 2", len(forest.Roots))
+	}
+	tests := []struct {
+		name     string
+		org      string
+		path     string
+		id       string
+		parent   string
+		children int
+	}{
+		{name: "root a", org: "org-a", path: "/Default", id: "a1", children: 2},
+		{name: "child a", org: "org-a", path: "/Default/Prod", id: "a2", parent: "a1", children: 1},
+		{name: "grandchild a", org: "org-a", path: "/Default/Prod/DB", id: "a3", parent: "a2"},
+		{name: "root b", org: "org-b", path: "/Default", id: "b1", children: 2},
+		{name: "child b", org: "org-b", path: "Default/Prod/", id: "b2", parent: "b1"},
+		{name: "only in b", org: "org-b", path: "/Default/Test", id: "b3", parent: "b1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, ok := forest.LookupOrg(tt.org, tt.path)
+			if !ok {
+				t.Fatalf("LookupOrg(%q, %q) not found", tt.org, tt.path)
+			}
+			if node.Node.Id != tt.id {
+				t.Errorf("LookupOrg(%q, %q) = %s, want %s", tt.org, tt.path, node.Node.Id, tt.id)
+			}
+			if byID, ok := forest.LookupID(tt.id); !ok || byID != node {
+				t.Errorf("LookupID(%q) does not return the node at %s", tt.id, tt.path)
+			}
+			parent := ""
+			if node.Parent != nil {
+				parent = node.Parent.Node.Id
+			}
+			if parent != tt.parent {
+				t.Errorf("LookupOrg(%q, %q) parent = %q, want %q", tt.org, tt.path, parent, tt.parent)
+			}
+			if len(node.Children) != tt.children {
+				t.Errorf("LookupOrg(%q, %q) has %d children, want %d", tt.org, tt.path, len(node.Children), tt.children)
+			}
+		})
+	}
+
+	// a path shared by both organizations is ambiguous without the organization
+	if _, ok := forest.Lookup("/Default/Prod"); ok {
+		t.Errorf("Lookup(%q) found a node, want none for a path of two organizations", "/Default/Prod")
+	}
+	if node, ok := forest.Lookup("/Default/Prod/DB"); !ok || node.Node.Id != "a3" {
+		t.Errorf("Lookup(%q) = %v, %v, want a3", "/Default/Prod/DB", node, ok)
+	}
+}
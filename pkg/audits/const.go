@@ -3,4 +3,14 @@ package audits
 const (
 	opertateLogListAPI = "/audits/operate-logs/"
 	opertateLogGetAPI  = "/audits/operate-logs/%s/"
+	loginLogListAPI    = "/audits/login-logs/"
+	loginLogGetAPI     = "/audits/login-logs/%s/"
+	ftpLogListAPI      = "/audits/ftp-logs/"
+	ftpLogGetAPI       = "/audits/ftp-logs/%s/"
+	ftpLogDownloadAPI  = "/audits/ftp-logs/%s/file/download/"
+	passwordLogListAPI = "/audits/password-change-logs/"
+	passwordLogGetAPI  = "/audits/password-change-logs/%s/"
+	userSessionListAPI = "/audits/user-sessions/"
+	userSessionGetAPI  = "/audits/user-sessions/%s/"
+	userSessionOffAPI  = "/audits/user-sessions/offline/"
 )
@@ -2,6 +2,7 @@ package audits
 
 // OperateFilter represents a filter for operation requests.
 // filter for api: /audits/operate-logs/
+// DateFrom and DateTo bound the log datetime, for example '2024-01-01T00:00:00Z'.
 type OperateFilter struct {
 	User         string `url:"user"`
 	Action       string `url:"action"`
@@ -12,4 +13,74 @@ type OperateFilter struct {
 	Order        string `url:"order"`
 	Limit        int    `url:"limit"`
 	Offset       int    `url:"offset"`
+	DateFrom     string `url:"date_from,omitempty"`
+	DateTo       string `url:"date_to,omitempty"`
+}
+
+// LoginLogFilter represents a filter for login log requests.
+// filter for api: /audits/login-logs/
+// Status is 'true' for successful logins and 'false' for failed ones.
+// DateFrom and DateTo bound the login datetime, for example '2024-01-01T00:00:00Z'.
+type LoginLogFilter struct {
+	Username string `url:"username,omitempty"`
+	IP       string `url:"ip,omitempty"`
+	City     string `url:"city,omitempty"`
+	Type     string `url:"type,omitempty"`
+	MFA      string `url:"mfa,omitempty"`
+	Status   string `url:"status,omitempty"`
+	Search   string `url:"search,omitempty"`
+	Order    string `url:"order,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+	Offset   int    `url:"offset,omitempty"`
+	DateFrom string `url:"date_from,omitempty"`
+	DateTo   string `url:"date_to,omitempty"`
+}
+
+// FTPLogFilter represents a filter for file transfer log requests.
+// filter for api: /audits/ftp-logs/
+// Operate is the transfer type, for example 'upload' or 'download', and IsSuccess is 'true' or 'false'.
+type FTPLogFilter struct {
+	User       string `url:"user,omitempty"`
+	Asset      string `url:"asset,omitempty"`
+	Account    string `url:"account,omitempty"`
+	Filename   string `url:"filename,omitempty"`
+	Operate    string `url:"operate,omitempty"`
+	IsSuccess  string `url:"is_success,omitempty"`
+	Session    string `url:"session,omitempty"`
+	RemoteAddr string `url:"remote_addr,omitempty"`
+	Search     string `url:"search,omitempty"`
+	Order      string `url:"order,omitempty"`
+	Limit      int    `url:"limit,omitempty"`
+	Offset     int    `url:"offset,omitempty"`
+	DateFrom   string `url:"date_from,omitempty"`
+	DateTo     string `url:"date_to,omitempty"`
+}
+
+// PasswordChangeLogFilter represents a filter for password change log requests.
+// filter for api: /audits/password-change-logs/
+type PasswordChangeLogFilter struct {
+	User       string `url:"user,omitempty"`
+	ChangeBy   string `url:"change_by,omitempty"`
+	RemoteAddr string `url:"remote_addr,omitempty"`
+	Search     string `url:"search,omitempty"`
+	Order      string `url:"order,omitempty"`
+	Limit      int    `url:"limit,omitempty"`
+	Offset     int    `url:"offset,omitempty"`
+	DateFrom   string `url:"date_from,omitempty"`
+	DateTo     string `url:"date_to,omitempty"`
+}
+
+// UserSessionFilter represents a filter for user web session requests.
+// filter for api: /audits/user-sessions/
+// IsActive is 'true' to list only the sessions still online.
+type UserSessionFilter struct {
+	User     string `url:"user,omitempty"`
+	IP       string `url:"ip,omitempty"`
+	City     string `url:"city,omitempty"`
+	Type     string `url:"type,omitempty"`
+	IsActive string `url:"is_active,omitempty"`
+	Search   string `url:"search,omitempty"`
+	Order    string `url:"order,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+	Offset   int    `url:"offset,omitempty"`
 }
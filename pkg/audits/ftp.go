@@ -0,0 +1,149 @@
+package audits
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"io"
+	"net/http"
+)
+
+// FTPLogs is a structure that holds configuration for the JmsAPI.
+// It is used to read the file transfer logs of the sessions and download the archived files.
+type FTPLogs struct {
+	API apiauth.JmsAPI
+}
+
+// FTPLogRep represents a file transfer done by a user on an asset.
+// HasFile is true when the server has archived the transferred file, which can then be downloaded.
+type FTPLogRep struct {
+	Id         string `json:"id"`
+	User       string `json:"user"`
+	RemoteAddr string `json:"remote_addr"`
+	Asset      string `json:"asset"`
+	Account    string `json:"account"`
+	Operate    struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"operate"`
+	Filename  string `json:"filename"`
+	IsSuccess bool   `json:"is_success"`
+	HasFile   bool   `json:"has_file"`
+	Session   string `json:"session"`
+	OrgId     string `json:"org_id"`
+	DateStart string `json:"date_start"`
+}
+
+// FTPLogListRep is the paginated response of the file transfer logs list endpoint.
+type FTPLogListRep = apiauth.ListRep[FTPLogRep]
+
+// Get retrieves the file transfer log identified by id.
+func (f *FTPLogs) Get(id string) (*FTPLogRep, error) {
+	return f.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but uses ctx for the underlying HTTP request.
+func (f *FTPLogs) GetWithContext(ctx context.Context, id string) (*FTPLogRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("ftp log id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(f.API.GetEndpoint(), fmt.Sprintf(ftpLogGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, f.API, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	data := &FTPLogRep{}
+	err = f.API.DoRequest(req, data)
+	return data, err
+}
+
+// List retrieves one page of the file transfer logs matching filter, or every log when filter sets no Limit.
+func (f *FTPLogs) List(filter *FTPLogFilter) (*FTPLogListRep, error) {
+	return f.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List but uses ctx for the underlying HTTP request.
+func (f *FTPLogs) ListWithContext(ctx context.Context, filter *FTPLogFilter) (*FTPLogListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(f.API.GetEndpoint(), ftpLogListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[FTPLogRep](ctx, f.API, endpoint, v)
+}
+
+// ListAll fetches every page of the file transfer logs matching filter and returns all of them.
+func (f *FTPLogs) ListAll(filter *FTPLogFilter) ([]FTPLogRep, error) {
+	return f.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (f *FTPLogs) ListAllWithContext(ctx context.Context, filter *FTPLogFilter) ([]FTPLogRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(f.API.GetEndpoint(), ftpLogListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[FTPLogRep](ctx, f.API, endpoint, v)
+}
+
+// Pages returns a pager over the file transfer logs matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (f *FTPLogs) Pages(filter *FTPLogFilter) *apiauth.Pager[FTPLogRep] {
+	return apiauth.NewQueryPager[FTPLogRep](f.API, utils.CombineURL(f.API.GetEndpoint(), ftpLogListAPI), filter)
+}
+
+// Iter returns an iterator over every file transfer log matching filter.
+func (f *FTPLogs) Iter(filter *FTPLogFilter) *apiauth.Iterator[FTPLogRep] {
+	return apiauth.NewQueryIterator[FTPLogRep](f.API, utils.CombineURL(f.API.GetEndpoint(), ftpLogListAPI), filter)
+}
+
+// Download returns the content of the file archived for the file transfer log identified by id.
+// It is only available when the log HasFile. The caller must close the returned reader.
+func (f *FTPLogs) Download(id string) (io.ReadCloser, error) {
+	return f.DownloadWithContext(context.Background(), id)
+}
+
+// DownloadWithContext is like Download but uses ctx for the underlying HTTP request,
+// cancelling ctx also aborts the reading of the content.
+func (f *FTPLogs) DownloadWithContext(ctx context.Context, id string) (io.ReadCloser, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("ftp log id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(f.API.GetEndpoint(), fmt.Sprintf(ftpLogDownloadAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, f.API, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	resp, err := apiauth.DoRawRequest(f.API, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,80 @@
+package audits
+
+import (
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// plainAPI implements only apiauth.JmsAPI, without the raw requests.
+type plainAPI struct {
+	apiauth.JmsAPI
+}
+
+func TestFTPLogsGet(t *testing.T) {
+	var urls []*url.URL
+	api := newTestServer(t, `{"id":"f1","filename":"/tmp/a.txt","operate":{"value":"upload","label":"Upload"},"is_success":true,"has_file":true}`, &urls)
+	log, err := (&FTPLogs{API: api}).Get("f1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if log.Filename != "/tmp/a.txt" || log.Operate.Value != "upload" || !log.IsSuccess || !log.HasFile {
+		t.Errorf("Get() = %+v, want the decoded log", log)
+	}
+	if len(urls) != 1 || urls[0].Path != "/audits/ftp-logs/f1/" {
+		t.Errorf("Get() sent %v, want one request to /audits/ftp-logs/f1/", urls)
+	}
+	if _, err := (&FTPLogs{API: api}).Get(""); err == nil {
+		t.Errorf("Get(\"\") error = nil, want an error")
+	}
+}
+
+func TestFTPLogsDownload(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		plain   bool
+		want    string
+		wantErr func(error) bool
+	}{
+		{name: "archived file", status: http.StatusOK, body: "file content", want: "file content"},
+		{name: "no archived file", status: http.StatusNotFound, body: `{"detail":"Not found."}`, wantErr: apiauth.IsNotFound},
+		{name: "api without raw requests", plain: true, wantErr: func(err error) bool { return err != nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/audits/ftp-logs/f1/file/download/" {
+					t.Errorf("request sent to %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			var api apiauth.JmsAPI = &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+			if tt.plain {
+				api = plainAPI{api}
+			}
+			content, err := (&FTPLogs{API: api}).Download("f1")
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("Download() error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Download() error = %v", err)
+			}
+			defer content.Close()
+			data, err := io.ReadAll(content)
+			if err != nil || string(data) != tt.want {
+				t.Errorf("Download() content = %q, %v, want %q", data, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package audits
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// LoginLog is a structure that holds configuration for the JmsAPI.
+// It is used to read the login logs of the users.
+type LoginLog struct {
+	API apiauth.JmsAPI
+}
+
+// LoginLogRep represents a login attempt of a user.
+// Status.Value is true for a successful login, Reason explains a failed one.
+type LoginLogRep struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+	Type     struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"type"`
+	IP        string `json:"ip"`
+	City      string `json:"city"`
+	UserAgent string `json:"user_agent"`
+	MFA       struct {
+		Value int    `json:"value"`
+		Label string `json:"label"`
+	} `json:"mfa"`
+	Reason        string `json:"reason"`
+	ReasonDisplay string `json:"reason_display"`
+	Backend       string `json:"backend"`
+	Status        struct {
+		Value bool   `json:"value"`
+		Label string `json:"label"`
+	} `json:"status"`
+	Datetime string `json:"datetime"`
+}
+
+// LoginLogListRep is the paginated response of the login logs list endpoint.
+type LoginLogListRep = apiauth.ListRep[LoginLogRep]
+
+// LoginFailureGroup holds the failed logins of a username from a source IP.
+// First and Last are the datetimes of the oldest and the newest failure, Reasons counts the failures per reason.
+type LoginFailureGroup struct {
+	Username string
+	IP       string
+	Count    int
+	First    string
+	Last     string
+	Reasons  map[string]int
+}
+
+// Get retrieves the login log identified by id.
+func (l *LoginLog) Get(id string) (*LoginLogRep, error) {
+	return l.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but uses ctx for the underlying HTTP request.
+func (l *LoginLog) GetWithContext(ctx context.Context, id string) (*LoginLogRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("login log id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(l.API.GetEndpoint(), fmt.Sprintf(loginLogGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, l.API, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	data := &LoginLogRep{}
+	err = l.API.DoRequest(req, data)
+	return data, err
+}
+
+// List retrieves one page of the login logs matching filter, or every log when filter sets no Limit.
+func (l *LoginLog) List(filter *LoginLogFilter) (*LoginLogListRep, error) {
+	return l.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List but uses ctx for the underlying HTTP request.
+func (l *LoginLog) ListWithContext(ctx context.Context, filter *LoginLogFilter) (*LoginLogListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(l.API.GetEndpoint(), loginLogListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[LoginLogRep](ctx, l.API, endpoint, v)
+}
+
+// ListAll fetches every page of the login logs matching filter and returns all of them.
+func (l *LoginLog) ListAll(filter *LoginLogFilter) ([]LoginLogRep, error) {
+	return l.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (l *LoginLog) ListAllWithContext(ctx context.Context, filter *LoginLogFilter) ([]LoginLogRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(l.API.GetEndpoint(), loginLogListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[LoginLogRep](ctx, l.API, endpoint, v)
+}
+
+// Pages returns a pager over the login logs matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (l *LoginLog) Pages(filter *LoginLogFilter) *apiauth.Pager[LoginLogRep] {
+	return apiauth.NewQueryPager[LoginLogRep](l.API, utils.CombineURL(l.API.GetEndpoint(), loginLogListAPI), filter)
+}
+
+// Iter returns an iterator over every login log matching filter.
+func (l *LoginLog) Iter(filter *LoginLogFilter) *apiauth.Iterator[LoginLogRep] {
+	return apiauth.NewQueryIterator[LoginLogRep](l.API, utils.CombineURL(l.API.GetEndpoint(), loginLogListAPI), filter)
+}
+
+// Failures fetches the failed logins of the last window and groups them by username and source IP.
+// The groups are sorted by descending count, so the most likely brute-force sources come first.
+func (l *LoginLog) Failures(window time.Duration) ([]LoginFailureGroup, error) {
+	return l.FailuresWithContext(context.Background(), window)
+}
+
+// FailuresWithContext is like Failures but issues every page request with ctx.
+func (l *LoginLog) FailuresWithContext(ctx context.Context, window time.Duration) ([]LoginFailureGroup, error) {
+	now := time.Now().UTC()
+	logs, err := l.ListAllWithContext(ctx, &LoginLogFilter{
+		Status:   "false",
+		DateFrom: now.Add(-window).Format(time.RFC3339),
+		DateTo:   now.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GroupLoginFailures(logs), nil
+}
+
+// GroupLoginFailures groups the failed logins of logs by username and source IP, successful logins are skipped.
+// The groups are sorted by descending count, then by username and IP.
+func GroupLoginFailures(logs []LoginLogRep) []LoginFailureGroup {
+	type groupKey struct {
+		username string
+		ip       string
+	}
+
+	// group failures
+	groups := make(map[groupKey]*LoginFailureGroup)
+	for _, log := range logs {
+		if log.Status.Value {
+			continue
+		}
+		key := groupKey{username: log.Username, ip: log.IP}
+		group, ok := groups[key]
+		if !ok {
+			group = &LoginFailureGroup{
+				Username: log.Username,
+				IP:       log.IP,
+				First:    log.Datetime,
+				Last:     log.Datetime,
+				Reasons:  make(map[string]int),
+			}
+			groups[key] = group
+		}
+		group.Count++
+		if dateBefore(log.Datetime, group.First) {
+			group.First = log.Datetime
+		}
+		if dateBefore(group.Last, log.Datetime) {
+			group.Last = log.Datetime
+		}
+		reason := log.ReasonDisplay
+		if reason == "" {
+			reason = log.Reason
+		}
+		group.Reasons[reason]++
+	}
+
+	// sort groups
+	result := make([]LoginFailureGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if result[i].Username != result[j].Username {
+			return result[i].Username < result[j].Username
+		}
+		return result[i].IP < result[j].IP
+	})
+	return result
+}
+
+// dateBefore reports whether the server date a is before b. The dates are compared as times, whatever their
+// format and offset, and as strings when one of them can not be parsed.
+func dateBefore(a, b string) bool {
+	ta, errA := utils.ParseDate(a)
+	tb, errB := utils.ParseDate(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return ta.Before(tb)
+}
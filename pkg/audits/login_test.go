@@ -0,0 +1,90 @@
+package audits
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// loginLog returns a login log of username from ip at datetime, successful when ok.
+func loginLog(username, ip, datetime, reason string, ok bool) LoginLogRep {
+	log := LoginLogRep{Username: username, IP: ip, Datetime: datetime, ReasonDisplay: reason}
+	log.Status.Value = ok
+	return log
+}
+
+func TestGroupLoginFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		logs []LoginLogRep
+		want []LoginFailureGroup
+	}{
+		{
+			name: "no failure",
+			logs: []LoginLogRep{loginLog("admin", "10.0.0.1", "2024/01/01 10:00:00 +0800", "", true)},
+			want: []LoginFailureGroup{},
+		},
+		{
+			name: "grouped by username and ip",
+			logs: []LoginLogRep{
+				loginLog("admin", "10.0.0.1", "2024/01/01 10:00:00 +0800", "Password failed", false),
+				loginLog("admin", "10.0.0.2", "2024/01/01 10:01:00 +0800", "Password failed", false),
+				loginLog("admin", "10.0.0.1", "2024/01/01 10:02:00 +0800", "MFA failed", false),
+				loginLog("admin", "10.0.0.1", "2024/01/01 10:03:00 +0800", "", true),
+			},
+			want: []LoginFailureGroup{
+				{Username: "admin", IP: "10.0.0.1", Count: 2, First: "2024/01/01 10:00:00 +0800", Last: "2024/01/01 10:02:00 +0800",
+					Reasons: map[string]int{"Password failed": 1, "MFA failed": 1}},
+				{Username: "admin", IP: "10.0.0.2", Count: 1, First: "2024/01/01 10:01:00 +0800", Last: "2024/01/01 10:01:00 +0800",
+					Reasons: map[string]int{"Password failed": 1}},
+			},
+		},
+		{
+			// 09:30 +0000 is 17:30 +0800, after 12:00 +0800 although it sorts first as a string
+			name: "offsets and formats",
+			logs: []LoginLogRep{
+				loginLog("root", "10.0.0.1", "2024/01/01 12:00:00 +0800", "Password failed", false),
+				loginLog("root", "10.0.0.1", "2024-01-01T09:30:00Z", "Password failed", false),
+				loginLog("root", "10.0.0.1", "2024-01-01T11:00:00.5+08:00", "Password failed", false),
+			},
+			want: []LoginFailureGroup{
+				{Username: "root", IP: "10.0.0.1", Count: 3, First: "2024-01-01T11:00:00.5+08:00", Last: "2024-01-01T09:30:00Z",
+					Reasons: map[string]int{"Password failed": 3}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GroupLoginFailures(tt.logs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GroupLoginFailures() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginLogFailures(t *testing.T) {
+	var urls []*url.URL
+	api := newTestServer(t, `[{"username":"admin","ip":"10.0.0.1","status":{"value":false},"datetime":"2024/01/01 10:00:00 +0800"}]`, &urls)
+	groups, err := (&LoginLog{API: api}).Failures(time.Hour)
+	if err != nil {
+		t.Fatalf("Failures() error = %v", err)
+	}
+	if len(groups) != 1 || groups[0].Count != 1 {
+		t.Errorf("Failures() = %+v, want one group of one failure", groups)
+	}
+	if len(urls) != 1 || urls[0].Path != "/audits/login-logs/" {
+		t.Fatalf("Failures() sent %v, want one request to /audits/login-logs/", urls)
+	}
+	query := urls[0].Query()
+	from, errFrom := time.Parse(time.RFC3339, query.Get("date_from"))
+	to, errTo := time.Parse(time.RFC3339, query.Get("date_to"))
+	if query.Get("status") != "false" || errFrom != nil || errTo != nil || to.Sub(from) != time.Hour {
+		t.Errorf("Failures() query = %v, want the failed logins of the last hour", query)
+	}
+	for _, k := range []string{"username", "ip", "search"} {
+		if query.Has(k) {
+			t.Errorf("Failures() query %s is sent, want it omitted", k)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package audits
 
 import (
+	"context"
 	"fmt"
 	"github.com/MScuti/gojms/pkg/apiauth"
 	"github.com/MScuti/gojms/pkg/utils"
@@ -9,37 +10,64 @@ import (
 )
 
 // OperateLog is a structure that holds configuration for the JmsAPI.
-// It contains a single field of type gojms.JmsAPIConfig which is used to make API requests.
+// It contains a single field of type apiauth.JmsAPI which is used to make API requests.
 type OperateLog struct {
-	API apiauth.JmsAPIConfig
+	API apiauth.JmsAPI
 }
 
+// OperateLogDetailRep represents an operation log, the record of a create, update or delete
+// done by a user on a resource.
+// Diff is only returned by Get and holds the changed fields of the resource.
+type OperateLogDetailRep struct {
+	Id     string `json:"id"`
+	User   string `json:"user"`
+	Action struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"action"`
+	ResourceType string      `json:"resource_type"`
+	Resource     string      `json:"resource"`
+	RemoteAddr   string      `json:"remote_addr"`
+	Datetime     string      `json:"datetime"`
+	OrgId        string      `json:"org_id"`
+	Diff         interface{} `json:"diff"`
+}
+
+// OperateLogListRep is the paginated response of the operation logs list endpoint.
+type OperateLogListRep = apiauth.ListRep[OperateLogDetailRep]
+
 // Get is a method on the OperateLog struct.
 // It receives a string id as a parameter.
 // The method checks if the id is non-empty and combines the API endpoint before making an http.Request.
 // If the id is empty, the method returns an error.
-// Otherwise, it creates a GET http.Request using the id to form the request URL
-// and then executes the request.
-// If the request execution is successful, the method returns nil. Otherwise, it returns the error from the request execution.
-func (o *OperateLog) Get(id string) error {
+// Otherwise, it creates a GET http.Request using the id to form the request URL,
+// executes the request and decodes the response into an OperateLogDetailRep.
+func (o *OperateLog) Get(id string) (*OperateLogDetailRep, error) {
+	return o.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but uses ctx for the underlying HTTP request,
+// so the call is aborted when ctx is cancelled or its deadline expires.
+func (o *OperateLog) GetWithContext(ctx context.Context, id string) (*OperateLogDetailRep, error) {
 	// check id
 	if id == "" {
-		return fmt.Errorf("operation log id can not empty")
+		return nil, fmt.Errorf("operation log id can not empty")
 	}
 
 	// combine api endpoint
-	endpoint := utils.CombineURL(o.API.Endpoints, opertateLogGetAPI)
+	endpoint := utils.CombineURL(o.API.GetEndpoint(), opertateLogGetAPI)
 	endpoint = fmt.Sprintf(endpoint, id)
 
 	// make request
-	req, err := o.API.MakeRequest(http.MethodGet, endpoint, nil)
+	req, err := apiauth.MakeRequestWithContext(ctx, o.API, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// do request
-	err = o.API.DoRequest(req, nil)
-	return err
+	data := &OperateLogDetailRep{}
+	err = o.API.DoRequest(req, data)
+	return data, err
 
 }
 
@@ -49,28 +77,54 @@ func (o *OperateLog) Get(id string) error {
 // and executes the request.
 // If filter is not nil, the method generates URL string parameters from
 // the filter object and appends it to the request.
-// If the request is successful, the method returns nil. If not, it returns an error.
-func (o *OperateLog) List(filter *OperateFilter) error {
+// If the request is successful, the method returns the decoded OperateLogListRep. If not, it returns an error.
+func (o *OperateLog) List(filter *OperateFilter) (*OperateLogListRep, error) {
+	return o.ListWithContext(context.Background(), filter)
+}
 
+// ListWithContext is like List but uses ctx for the underlying HTTP request,
+// so the call is aborted when ctx is cancelled or its deadline expires.
+func (o *OperateLog) ListWithContext(ctx context.Context, filter *OperateFilter) (*OperateLogListRep, error) {
 	// combine api endpoint
-	endpoint := utils.CombineURL(o.API.Endpoints, opertateLogListAPI)
+	endpoint := utils.CombineURL(o.API.GetEndpoint(), opertateLogListAPI)
 
-	// make request
-	req, err := o.API.MakeRequest(http.MethodGet, endpoint, nil)
+	// set query params
+	v, err := query.Values(filter)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	// do request
+	return apiauth.List[OperateLogDetailRep](ctx, o.API, endpoint, v)
+}
+
+// ListAll fetches every page of the operation logs matching filter and returns all of them.
+func (o *OperateLog) ListAll(filter *OperateFilter) ([]OperateLogDetailRep, error) {
+	return o.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (o *OperateLog) ListAllWithContext(ctx context.Context, filter *OperateFilter) ([]OperateLogDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(o.API.GetEndpoint(), opertateLogListAPI)
+
 	// set query params
-	if filter != nil {
-		v, err := query.Values(filter)
-		if err != nil {
-			return err
-		}
-		req = o.API.SetQuery(req, v)
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
 	}
 
 	// do request
-	err = o.API.DoRequest(req, nil)
-	return err
+	return apiauth.ListAll[OperateLogDetailRep](ctx, o.API, endpoint, v)
+}
+
+// Pages returns a pager over the operation logs matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (o *OperateLog) Pages(filter *OperateFilter) *apiauth.Pager[OperateLogDetailRep] {
+	return apiauth.NewQueryPager[OperateLogDetailRep](o.API, utils.CombineURL(o.API.GetEndpoint(), opertateLogListAPI), filter)
+}
+
+// Iter returns an iterator over every operation log matching filter.
+func (o *OperateLog) Iter(filter *OperateFilter) *apiauth.Iterator[OperateLogDetailRep] {
+	return apiauth.NewQueryIterator[OperateLogDetailRep](o.API, utils.CombineURL(o.API.GetEndpoint(), opertateLogListAPI), filter)
 }
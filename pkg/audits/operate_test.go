@@ -0,0 +1,74 @@
+package audits
+
+import (
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newTestServer returns an API answering every request with reply and recording the request URLs into urls.
+func newTestServer(t *testing.T, reply string, urls *[]*url.URL) apiauth.JmsAPI {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*urls = append(*urls, r.URL)
+		w.Write([]byte(reply))
+	}))
+	t.Cleanup(srv.Close)
+	return &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+}
+
+func TestOperateLogList(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *OperateFilter
+		want    url.Values
+		without []string
+	}{
+		{
+			name:    "no dates",
+			filter:  &OperateFilter{User: "admin", Limit: 10},
+			want:    url.Values{"user": {"admin"}, "limit": {"10"}, "offset": {"0"}},
+			without: []string{"date_from", "date_to"},
+		},
+		{
+			name:   "dates",
+			filter: &OperateFilter{DateFrom: "2024-01-01T00:00:00Z", DateTo: "2024-01-02T00:00:00Z", Limit: 10},
+			want:   url.Values{"date_from": {"2024-01-01T00:00:00Z"}, "date_to": {"2024-01-02T00:00:00Z"}},
+		},
+		{
+			name:    "no limit",
+			filter:  &OperateFilter{Action: "delete"},
+			want:    url.Values{"action": {"delete"}},
+			without: []string{"limit", "offset", "date_from", "date_to"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var urls []*url.URL
+			api := newTestServer(t, `{"count":1,"next":null,"previous":null,"results":[{"id":"l1","action":{"value":"delete","label":"Delete"}}]}`, &urls)
+			logs, err := (&OperateLog{API: api}).List(tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(logs.Results) != 1 || logs.Results[0].Action.Value != "delete" {
+				t.Errorf("List() = %+v, want the decoded log", logs.Results)
+			}
+			if len(urls) != 1 || urls[0].Path != "/audits/operate-logs/" {
+				t.Fatalf("List() sent %v, want one request to /audits/operate-logs/", urls)
+			}
+			query := urls[0].Query()
+			for k, v := range tt.want {
+				if query.Get(k) != v[0] {
+					t.Errorf("List() query %s = %q, want %q", k, query.Get(k), v[0])
+				}
+			}
+			for _, k := range tt.without {
+				if query.Has(k) {
+					t.Errorf("List() query %s is sent, want it omitted", k)
+				}
+			}
+		})
+	}
+}
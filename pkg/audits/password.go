@@ -0,0 +1,107 @@
+package audits
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+)
+
+// PasswordChangeLog is a structure that holds configuration for the JmsAPI.
+// It is used to read the password changes of the users.
+type PasswordChangeLog struct {
+	API apiauth.JmsAPI
+}
+
+// PasswordChangeLogRep represents a password change of a user.
+// ChangeBy is the user who changed the password, which differs from User when an administrator reset it.
+type PasswordChangeLogRep struct {
+	Id         string `json:"id"`
+	User       string `json:"user"`
+	ChangeBy   string `json:"change_by"`
+	RemoteAddr string `json:"remote_addr"`
+	Datetime   string `json:"datetime"`
+}
+
+// PasswordChangeLogListRep is the paginated response of the password change logs list endpoint.
+type PasswordChangeLogListRep = apiauth.ListRep[PasswordChangeLogRep]
+
+// Get retrieves the password change log identified by id.
+func (p *PasswordChangeLog) Get(id string) (*PasswordChangeLogRep, error) {
+	return p.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but uses ctx for the underlying HTTP request.
+func (p *PasswordChangeLog) GetWithContext(ctx context.Context, id string) (*PasswordChangeLogRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("password change log id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), fmt.Sprintf(passwordLogGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, p.API, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	data := &PasswordChangeLogRep{}
+	err = p.API.DoRequest(req, data)
+	return data, err
+}
+
+// List retrieves one page of the password change logs matching filter, or every log when filter sets no Limit.
+func (p *PasswordChangeLog) List(filter *PasswordChangeLogFilter) (*PasswordChangeLogListRep, error) {
+	return p.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List but uses ctx for the underlying HTTP request.
+func (p *PasswordChangeLog) ListWithContext(ctx context.Context, filter *PasswordChangeLogFilter) (*PasswordChangeLogListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), passwordLogListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[PasswordChangeLogRep](ctx, p.API, endpoint, v)
+}
+
+// ListAll fetches every page of the password change logs matching filter and returns all of them.
+func (p *PasswordChangeLog) ListAll(filter *PasswordChangeLogFilter) ([]PasswordChangeLogRep, error) {
+	return p.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (p *PasswordChangeLog) ListAllWithContext(ctx context.Context, filter *PasswordChangeLogFilter) ([]PasswordChangeLogRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), passwordLogListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[PasswordChangeLogRep](ctx, p.API, endpoint, v)
+}
+
+// Pages returns a pager over the password change logs matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (p *PasswordChangeLog) Pages(filter *PasswordChangeLogFilter) *apiauth.Pager[PasswordChangeLogRep] {
+	return apiauth.NewQueryPager[PasswordChangeLogRep](p.API, utils.CombineURL(p.API.GetEndpoint(), passwordLogListAPI), filter)
+}
+
+// Iter returns an iterator over every password change log matching filter.
+func (p *PasswordChangeLog) Iter(filter *PasswordChangeLogFilter) *apiauth.Iterator[PasswordChangeLogRep] {
+	return apiauth.NewQueryIterator[PasswordChangeLogRep](p.API, utils.CombineURL(p.API.GetEndpoint(), passwordLogListAPI), filter)
+}
@@ -0,0 +1,145 @@
+package audits
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+)
+
+// UserSessions is a structure that holds configuration for the JmsAPI.
+// It is used to read the web sessions of the users and to force them offline.
+type UserSessions struct {
+	API apiauth.JmsAPI
+}
+
+// UserSessionRep represents a web session of a user on JumpServer, not to be confused with
+// the terminal sessions on assets.
+// IsActive is true while the session is online.
+type UserSessionRep struct {
+	Id        string `json:"id"`
+	IP        string `json:"ip"`
+	City      string `json:"city"`
+	UserAgent string `json:"user_agent"`
+	Type      struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"type"`
+	Backend        string `json:"backend"`
+	BackendDisplay string `json:"backend_display"`
+	IsActive       bool   `json:"is_active"`
+	User           struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"user"`
+	DateCreated string `json:"date_created"`
+	DateExpired string `json:"date_expired"`
+}
+
+// UserSessionListRep is the paginated response of the user sessions list endpoint.
+type UserSessionListRep = apiauth.ListRep[UserSessionRep]
+
+// Get retrieves the user session identified by id.
+func (u *UserSessions) Get(id string) (*UserSessionRep, error) {
+	return u.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but uses ctx for the underlying HTTP request.
+func (u *UserSessions) GetWithContext(ctx context.Context, id string) (*UserSessionRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("user session id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(u.API.GetEndpoint(), fmt.Sprintf(userSessionGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, u.API, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	data := &UserSessionRep{}
+	err = u.API.DoRequest(req, data)
+	return data, err
+}
+
+// List retrieves one page of the user sessions matching filter, or every session when filter sets no Limit.
+func (u *UserSessions) List(filter *UserSessionFilter) (*UserSessionListRep, error) {
+	return u.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List but uses ctx for the underlying HTTP request.
+func (u *UserSessions) ListWithContext(ctx context.Context, filter *UserSessionFilter) (*UserSessionListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(u.API.GetEndpoint(), userSessionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[UserSessionRep](ctx, u.API, endpoint, v)
+}
+
+// ListAll fetches every page of the user sessions matching filter and returns all of them.
+func (u *UserSessions) ListAll(filter *UserSessionFilter) ([]UserSessionRep, error) {
+	return u.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (u *UserSessions) ListAllWithContext(ctx context.Context, filter *UserSessionFilter) ([]UserSessionRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(u.API.GetEndpoint(), userSessionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[UserSessionRep](ctx, u.API, endpoint, v)
+}
+
+// Pages returns a pager over the user sessions matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (u *UserSessions) Pages(filter *UserSessionFilter) *apiauth.Pager[UserSessionRep] {
+	return apiauth.NewQueryPager[UserSessionRep](u.API, utils.CombineURL(u.API.GetEndpoint(), userSessionListAPI), filter)
+}
+
+// Iter returns an iterator over every user session matching filter.
+func (u *UserSessions) Iter(filter *UserSessionFilter) *apiauth.Iterator[UserSessionRep] {
+	return apiauth.NewQueryIterator[UserSessionRep](u.API, utils.CombineURL(u.API.GetEndpoint(), userSessionListAPI), filter)
+}
+
+// Offline forces the user sessions identified by ids offline, the users are logged out of JumpServer.
+func (u *UserSessions) Offline(ids ...string) error {
+	return u.OfflineWithContext(context.Background(), ids...)
+}
+
+// OfflineWithContext is like Offline but uses ctx for the underlying HTTP request.
+func (u *UserSessions) OfflineWithContext(ctx context.Context, ids ...string) error {
+	// check ids
+	if len(ids) == 0 {
+		return fmt.Errorf("user session ids can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(u.API.GetEndpoint(), userSessionOffAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, u.API, http.MethodPost, endpoint, map[string][]string{"ids": ids})
+	if err != nil {
+		return err
+	}
+
+	// do request
+	return u.API.DoRequest(req, nil)
+}
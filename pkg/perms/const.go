@@ -0,0 +1,11 @@
+package perms
+
+const (
+	assetPermissionGetAPI  = "/perms/asset-permissions/%s/"
+	assetPermissionListAPI = "/perms/asset-permissions/"
+
+	permUserRelationsAPI      = "/perms/asset-permissions-users-relations/"
+	permUserGroupRelationsAPI = "/perms/asset-permissions-user-groups-relations/"
+	permAssetRelationsAPI     = "/perms/asset-permissions-assets-relations/"
+	permNodeRelationsAPI      = "/perms/asset-permissions-nodes-relations/"
+)
@@ -0,0 +1,25 @@
+package perms
+
+// AssetPermissionFilter represents the filters that can be applied when querying the asset permissions.
+// UserID, UserGroupID, AssetID, NodeID: Filter the permissions granted to a user or a user group,
+// or on an asset or a node. The user and asset filters also match the permissions granted through
+// a group or a node unless All is 0.
+// Accounts: Filter the permissions granting an account, for example 'root' or '@ALL'.
+// IsValid: Filter the permissions active and within their validity period.
+type AssetPermissionFilter struct {
+	Name        string `url:"name,omitempty"`
+	UserID      string `url:"user_id,omitempty"`
+	Username    string `url:"username,omitempty"`
+	UserGroupID string `url:"user_group_id,omitempty"`
+	AssetID     string `url:"asset_id,omitempty"`
+	Address     string `url:"address,omitempty"`
+	NodeID      string `url:"node_id,omitempty"`
+	Accounts    string `url:"accounts,omitempty"`
+	All         string `url:"all,omitempty"`
+	IsValid     string `url:"is_valid,omitempty"`
+	IsActive    string `url:"is_active,omitempty"`
+	Search      string `url:"search,omitempty"`
+	Order       string `url:"order,omitempty"`
+	Limit       int    `url:"limit,omitempty"`
+	Offset      int    `url:"offset,omitempty"`
+}
@@ -0,0 +1,423 @@
+package perms
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// AccountAll grants every account of the permitted assets.
+	AccountAll = "@ALL"
+	// AccountSpec grants only the accounts listed by username next to it.
+	AccountSpec = "@SPEC"
+	// AccountInput lets the user type any username and secret when connecting.
+	AccountInput = "@INPUT"
+	// AccountUser connects with the username and password of the JumpServer user.
+	AccountUser = "@USER"
+	// AccountAnon connects without any account, used by the web assets.
+	AccountAnon = "@ANON"
+)
+
+const (
+	// ProtocolAll grants every protocol of the permitted assets.
+	ProtocolAll = "all"
+)
+
+const (
+	// ActionConnect allows to connect to the assets.
+	ActionConnect = "connect"
+	// ActionUpload allows to upload files to the assets.
+	ActionUpload = "upload"
+	// ActionDownload allows to download files from the assets.
+	ActionDownload = "download"
+	// ActionCopy allows to copy from the sessions to the clipboard.
+	ActionCopy = "copy"
+	// ActionPaste allows to paste from the clipboard into the sessions.
+	ActionPaste = "paste"
+	// ActionDelete allows to delete files on the assets.
+	ActionDelete = "delete"
+	// ActionShare allows to share the sessions with other users.
+	ActionShare = "share"
+)
+
+// AssetPermission is a struct that holds configuration for the JmsAPI.
+// It is used to manage the asset permissions, the rules granting users and user groups access
+// to assets and nodes with some accounts, protocols and actions.
+type AssetPermission struct {
+	API apiauth.JmsAPI
+}
+
+// AssetPermissionRep represents an asset permission.
+// IsValid is true while the permission is active and within its validity period,
+// FromTicket tells if it was created by the approval of a ticket.
+type AssetPermissionRep struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Users []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"users"`
+	UserGroups []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"user_groups"`
+	Assets []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"assets"`
+	Nodes []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"nodes"`
+	Accounts  []string `json:"accounts"`
+	Protocols []string `json:"protocols"`
+	Actions   []struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"actions"`
+	IsActive    bool   `json:"is_active"`
+	IsExpired   bool   `json:"is_expired"`
+	IsValid     bool   `json:"is_valid"`
+	FromTicket  bool   `json:"from_ticket"`
+	DateStart   string `json:"date_start"`
+	DateExpired string `json:"date_expired"`
+	Comment     string `json:"comment"`
+	OrgId       string `json:"org_id"`
+	OrgName     string `json:"org_name"`
+	CreatedBy   string `json:"created_by"`
+	DateCreated string `json:"date_created"`
+}
+
+// AssetPermissionListRep is the paginated response of the asset permissions list endpoint.
+type AssetPermissionListRep = apiauth.ListRep[AssetPermissionRep]
+
+// AssetPermissionReq is the request body used to create or update an asset permission.
+// Users, UserGroups, Assets and Nodes hold ids. Accounts holds usernames and the AccountXxx constants,
+// for example []string{AccountSpec, "deploy"}. Protocols holds protocol names or ProtocolAll and Actions
+// the ActionXxx constants. DateStart and DateExpired bound the validity, for example '2024-01-01T00:00:00Z'.
+// Empty lists and a nil IsActive are not sent and left to the server, use PartialUpdate to empty a list.
+type AssetPermissionReq struct {
+	Name        string   `json:"name"`
+	Users       []string `json:"users,omitempty"`
+	UserGroups  []string `json:"user_groups,omitempty"`
+	Assets      []string `json:"assets,omitempty"`
+	Nodes       []string `json:"nodes,omitempty"`
+	Accounts    []string `json:"accounts,omitempty"`
+	Protocols   []string `json:"protocols,omitempty"`
+	Actions     []string `json:"actions,omitempty"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+	DateStart   string   `json:"date_start,omitempty"`
+	DateExpired string   `json:"date_expired,omitempty"`
+	Comment     string   `json:"comment,omitempty"`
+}
+
+// AssetPermissionPatchReq is the request body used to partially update an asset permission.
+// Only the non nil fields are sent.
+type AssetPermissionPatchReq struct {
+	Name        *string   `json:"name,omitempty"`
+	Users       *[]string `json:"users,omitempty"`
+	UserGroups  *[]string `json:"user_groups,omitempty"`
+	Assets      *[]string `json:"assets,omitempty"`
+	Nodes       *[]string `json:"nodes,omitempty"`
+	Accounts    *[]string `json:"accounts,omitempty"`
+	Protocols   *[]string `json:"protocols,omitempty"`
+	Actions     *[]string `json:"actions,omitempty"`
+	IsActive    *bool     `json:"is_active,omitempty"`
+	DateStart   *string   `json:"date_start,omitempty"`
+	DateExpired *string   `json:"date_expired,omitempty"`
+	Comment     *string   `json:"comment,omitempty"`
+}
+
+// Get retrieves the asset permission identified by id.
+func (p *AssetPermission) Get(id string) (*AssetPermissionRep, error) {
+	return p.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but carries ctx through to the HTTP request.
+func (p *AssetPermission) GetWithContext(ctx context.Context, id string) (*AssetPermissionRep, error) {
+	return p.send(ctx, http.MethodGet, id, nil)
+}
+
+// List retrieves one page of the asset permissions matching filter, or every permission when filter sets no Limit.
+func (p *AssetPermission) List(filter *AssetPermissionFilter) (*AssetPermissionListRep, error) {
+	return p.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List but carries ctx through to the HTTP request.
+func (p *AssetPermission) ListWithContext(ctx context.Context, filter *AssetPermissionFilter) (*AssetPermissionListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), assetPermissionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[AssetPermissionRep](ctx, p.API, endpoint, v)
+}
+
+// ListAll fetches every page of the asset permissions matching filter and returns all of them.
+func (p *AssetPermission) ListAll(filter *AssetPermissionFilter) ([]AssetPermissionRep, error) {
+	return p.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but carries ctx through to every page request.
+func (p *AssetPermission) ListAllWithContext(ctx context.Context, filter *AssetPermissionFilter) ([]AssetPermissionRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), assetPermissionListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[AssetPermissionRep](ctx, p.API, endpoint, v)
+}
+
+// Pages returns a pager over the asset permissions matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (p *AssetPermission) Pages(filter *AssetPermissionFilter) *apiauth.Pager[AssetPermissionRep] {
+	return apiauth.NewQueryPager[AssetPermissionRep](p.API, utils.CombineURL(p.API.GetEndpoint(), assetPermissionListAPI), filter)
+}
+
+// Iter returns an iterator over every asset permission matching filter, fetching the pages on demand.
+func (p *AssetPermission) Iter(filter *AssetPermissionFilter) *apiauth.Iterator[AssetPermissionRep] {
+	return apiauth.NewQueryIterator[AssetPermissionRep](p.API, utils.CombineURL(p.API.GetEndpoint(), assetPermissionListAPI), filter)
+}
+
+// Create creates an asset permission from data and returns it.
+func (p *AssetPermission) Create(data *AssetPermissionReq) (*AssetPermissionRep, error) {
+	return p.CreateWithContext(context.Background(), data)
+}
+
+// CreateWithContext is like Create but carries ctx through to the HTTP request.
+func (p *AssetPermission) CreateWithContext(ctx context.Context, data *AssetPermissionReq) (*AssetPermissionRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), assetPermissionListAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, p.API, http.MethodPost, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	rep := &AssetPermissionRep{}
+	err = p.API.DoRequest(req, rep)
+	return rep, err
+}
+
+// Update replaces the asset permission identified by id with data and returns it.
+func (p *AssetPermission) Update(id string, data *AssetPermissionReq) (*AssetPermissionRep, error) {
+	return p.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is like Update but carries ctx through to the HTTP request.
+func (p *AssetPermission) UpdateWithContext(ctx context.Context, id string, data *AssetPermissionReq) (*AssetPermissionRep, error) {
+	return p.send(ctx, http.MethodPut, id, data)
+}
+
+// PartialUpdate updates the non nil fields of data on the asset permission identified by id and returns it.
+func (p *AssetPermission) PartialUpdate(id string, data *AssetPermissionPatchReq) (*AssetPermissionRep, error) {
+	return p.PartialUpdateWithContext(context.Background(), id, data)
+}
+
+// PartialUpdateWithContext is like PartialUpdate but carries ctx through to the HTTP request.
+func (p *AssetPermission) PartialUpdateWithContext(ctx context.Context, id string, data *AssetPermissionPatchReq) (*AssetPermissionRep, error) {
+	return p.send(ctx, http.MethodPatch, id, data)
+}
+
+// Delete deletes the asset permission identified by id, the access it granted is revoked.
+func (p *AssetPermission) Delete(id string) error {
+	return p.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete but carries ctx through to the HTTP request.
+func (p *AssetPermission) DeleteWithContext(ctx context.Context, id string) error {
+	_, err := p.send(ctx, http.MethodDelete, id, nil)
+	return err
+}
+
+// BulkDelete deletes every asset permission identified by ids in a single request.
+func (p *AssetPermission) BulkDelete(ids []string) error {
+	return p.BulkDeleteWithContext(context.Background(), ids)
+}
+
+// BulkDeleteWithContext is like BulkDelete but carries ctx through to the HTTP requests.
+func (p *AssetPermission) BulkDeleteWithContext(ctx context.Context, ids []string) error {
+	return apiauth.DeleteResources(ctx, p.API, utils.CombineURL(p.API.GetEndpoint(), assetPermissionListAPI), ids)
+}
+
+// AddUsers grants the asset permission identified by id to the users identified by userIDs in a single request.
+func (p *AssetPermission) AddUsers(id string, userIDs []string) error {
+	return p.AddUsersWithContext(context.Background(), id, userIDs)
+}
+
+// AddUsersWithContext is like AddUsers but carries ctx through to the HTTP request.
+func (p *AssetPermission) AddUsersWithContext(ctx context.Context, id string, userIDs []string) error {
+	return p.addRelations(ctx, permUserRelationsAPI, "user", id, userIDs)
+}
+
+// RemoveUsers revokes the asset permission identified by id from the users identified by userIDs.
+// The relations endpoint deletes one relation per request, so a request is sent for each user.
+func (p *AssetPermission) RemoveUsers(id string, userIDs []string) error {
+	return p.RemoveUsersWithContext(context.Background(), id, userIDs)
+}
+
+// RemoveUsersWithContext is like RemoveUsers but carries ctx through to the HTTP requests.
+func (p *AssetPermission) RemoveUsersWithContext(ctx context.Context, id string, userIDs []string) error {
+	return p.removeRelations(ctx, permUserRelationsAPI, "user", id, userIDs)
+}
+
+// AddUserGroups grants the asset permission identified by id to the user groups identified by groupIDs in a single request.
+func (p *AssetPermission) AddUserGroups(id string, groupIDs []string) error {
+	return p.AddUserGroupsWithContext(context.Background(), id, groupIDs)
+}
+
+// AddUserGroupsWithContext is like AddUserGroups but carries ctx through to the HTTP request.
+func (p *AssetPermission) AddUserGroupsWithContext(ctx context.Context, id string, groupIDs []string) error {
+	return p.addRelations(ctx, permUserGroupRelationsAPI, "usergroup", id, groupIDs)
+}
+
+// RemoveUserGroups revokes the asset permission identified by id from the user groups identified by groupIDs,
+// with a request for each group.
+func (p *AssetPermission) RemoveUserGroups(id string, groupIDs []string) error {
+	return p.RemoveUserGroupsWithContext(context.Background(), id, groupIDs)
+}
+
+// RemoveUserGroupsWithContext is like RemoveUserGroups but carries ctx through to the HTTP requests.
+func (p *AssetPermission) RemoveUserGroupsWithContext(ctx context.Context, id string, groupIDs []string) error {
+	return p.removeRelations(ctx, permUserGroupRelationsAPI, "usergroup", id, groupIDs)
+}
+
+// AddAssets adds the assets identified by assetIDs to the asset permission identified by id in a single request.
+func (p *AssetPermission) AddAssets(id string, assetIDs []string) error {
+	return p.AddAssetsWithContext(context.Background(), id, assetIDs)
+}
+
+// AddAssetsWithContext is like AddAssets but carries ctx through to the HTTP request.
+func (p *AssetPermission) AddAssetsWithContext(ctx context.Context, id string, assetIDs []string) error {
+	return p.addRelations(ctx, permAssetRelationsAPI, "asset", id, assetIDs)
+}
+
+// RemoveAssets removes the assets identified by assetIDs from the asset permission identified by id,
+// with a request for each asset.
+func (p *AssetPermission) RemoveAssets(id string, assetIDs []string) error {
+	return p.RemoveAssetsWithContext(context.Background(), id, assetIDs)
+}
+
+// RemoveAssetsWithContext is like RemoveAssets but carries ctx through to the HTTP requests.
+func (p *AssetPermission) RemoveAssetsWithContext(ctx context.Context, id string, assetIDs []string) error {
+	return p.removeRelations(ctx, permAssetRelationsAPI, "asset", id, assetIDs)
+}
+
+// AddNodes adds the nodes identified by nodeIDs to the asset permission identified by id in a single request.
+func (p *AssetPermission) AddNodes(id string, nodeIDs []string) error {
+	return p.AddNodesWithContext(context.Background(), id, nodeIDs)
+}
+
+// AddNodesWithContext is like AddNodes but carries ctx through to the HTTP request.
+func (p *AssetPermission) AddNodesWithContext(ctx context.Context, id string, nodeIDs []string) error {
+	return p.addRelations(ctx, permNodeRelationsAPI, "node", id, nodeIDs)
+}
+
+// RemoveNodes removes the nodes identified by nodeIDs from the asset permission identified by id,
+// with a request for each node.
+func (p *AssetPermission) RemoveNodes(id string, nodeIDs []string) error {
+	return p.RemoveNodesWithContext(context.Background(), id, nodeIDs)
+}
+
+// RemoveNodesWithContext is like RemoveNodes but carries ctx through to the HTTP requests.
+func (p *AssetPermission) RemoveNodesWithContext(ctx context.Context, id string, nodeIDs []string) error {
+	return p.removeRelations(ctx, permNodeRelationsAPI, "node", id, nodeIDs)
+}
+
+// send sends data with method to the detail endpoint of the asset permission identified by id
+// and decodes the response into an AssetPermissionRep. DELETE responses have no content and return nil.
+func (p *AssetPermission) send(ctx context.Context, method, id string, data interface{}) (*AssetPermissionRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("asset permission id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), fmt.Sprintf(assetPermissionGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, p.API, method, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	if method == http.MethodDelete {
+		return nil, p.API.DoRequest(req, nil)
+	}
+	rep := &AssetPermissionRep{}
+	err = p.API.DoRequest(req, rep)
+	return rep, err
+}
+
+// addRelations posts a relation between the asset permission identified by id and each of ids, named field
+// in the relations, to the relations endpoint api in a single request.
+func (p *AssetPermission) addRelations(ctx context.Context, api, field, id string, ids []string) error {
+	// check params
+	if id == "" {
+		return fmt.Errorf("asset permission id can not empty")
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), api)
+
+	// make request
+	relations := make([]map[string]string, 0, len(ids))
+	for _, relID := range ids {
+		relations = append(relations, map[string]string{"assetpermission": id, field: relID})
+	}
+	req, err := apiauth.MakeRequestWithContext(ctx, p.API, http.MethodPost, endpoint, relations)
+	if err != nil {
+		return err
+	}
+
+	// do request
+	return p.API.DoRequest(req, nil)
+}
+
+// removeRelations deletes the relation between the asset permission identified by id and each of ids,
+// named field in the relations, from the relations endpoint api with a request per relation.
+func (p *AssetPermission) removeRelations(ctx context.Context, api, field, id string, ids []string) error {
+	// check id
+	if id == "" {
+		return fmt.Errorf("asset permission id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(p.API.GetEndpoint(), api)
+
+	for _, relID := range ids {
+		// make request
+		req, err := apiauth.MakeRequestWithContext(ctx, p.API, http.MethodDelete, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		req = p.API.SetQuery(req, url.Values{"assetpermission": []string{id}, field: []string{relID}})
+
+		// do request
+		if err = p.API.DoRequest(req, nil); err != nil {
+			return fmt.Errorf("remove %s %s from asset permission error: %w", field, relID, err)
+		}
+	}
+	return nil
+}
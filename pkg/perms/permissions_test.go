@@ -0,0 +1,146 @@
+package perms
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// recorded is a request received by the test server.
+type recorded struct {
+	Method string
+	URI    string
+	Body   string
+}
+
+// newTestServer returns a server recording every request into requests and answering with reply.
+func newTestServer(t *testing.T, reply string, requests *[]recorded) apiauth.JmsAPI {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, recorded{Method: r.Method, URI: r.URL.RequestURI(), Body: string(body)})
+		w.Write([]byte(reply))
+	}))
+	t.Cleanup(srv.Close)
+	return &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+}
+
+// jsonEqual reports whether a and b hold the same JSON value, or are both empty.
+func jsonEqual(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestAssetPermissionRequests(t *testing.T) {
+	empty := []string{}
+	tests := []struct {
+		name    string
+		reply   string
+		call    func(p *AssetPermission) error
+		want    []recorded
+		wantErr bool
+	}{
+		{
+			name: "create leaves empty lists and is_active to the server",
+			call: func(p *AssetPermission) error {
+				_, err := p.Create(&AssetPermissionReq{Name: "ops", Users: []string{"u1"}, Nodes: []string{}, Actions: []string{ActionConnect}})
+				return err
+			},
+			want: []recorded{{Method: http.MethodPost, URI: "/perms/asset-permissions/", Body: `{"name":"ops","users":["u1"],"actions":["connect"]}`}},
+		},
+		{
+			name: "partial update empties a list",
+			call: func(p *AssetPermission) error {
+				_, err := p.PartialUpdate("p1", &AssetPermissionPatchReq{Nodes: &empty})
+				return err
+			},
+			want: []recorded{{Method: http.MethodPatch, URI: "/perms/asset-permissions/p1/", Body: `{"nodes":[]}`}},
+		},
+		{
+			name: "add users in a single request",
+			call: func(p *AssetPermission) error { return p.AddUsers("p1", []string{"u1", "u2"}) },
+			want: []recorded{{Method: http.MethodPost, URI: "/perms/asset-permissions-users-relations/",
+				Body: `[{"assetpermission":"p1","user":"u1"},{"assetpermission":"p1","user":"u2"}]`}},
+		},
+		{
+			name: "add no assets",
+			call: func(p *AssetPermission) error { return p.AddAssets("p1", nil) },
+		},
+		{
+			name: "remove user groups one by one",
+			call: func(p *AssetPermission) error { return p.RemoveUserGroups("p1", []string{"g1", "g2"}) },
+			want: []recorded{
+				{Method: http.MethodDelete, URI: "/perms/asset-permissions-user-groups-relations/?assetpermission=p1&usergroup=g1"},
+				{Method: http.MethodDelete, URI: "/perms/asset-permissions-user-groups-relations/?assetpermission=p1&usergroup=g2"},
+			},
+		},
+		{
+			name:  "bulk delete",
+			reply: `{"spm":"key"}`,
+			call:  func(p *AssetPermission) error { return p.BulkDelete([]string{"p1", "p2"}) },
+			want: []recorded{
+				{Method: http.MethodPost, URI: "/common/resources/cache/", Body: `{"resources":["p1","p2"]}`},
+				{Method: http.MethodDelete, URI: "/perms/asset-permissions/?spm=key"},
+			},
+		},
+		{
+			name:  "pages",
+			reply: `{"count":3,"next":"/perms/asset-permissions/?limit=2&offset=2","results":[{"id":"p1"},{"id":"p2"}]}`,
+			call: func(p *AssetPermission) error {
+				pager := p.Pages(&AssetPermissionFilter{UserID: "u1", Limit: 2})
+				page, err := pager.Next(context.Background())
+				if err == nil && (len(page.Results) != 2 || !pager.HasNext()) {
+					t.Errorf("Next() = %d permissions, HasNext() = %v, want 2 and true", len(page.Results), pager.HasNext())
+				}
+				return err
+			},
+			want: []recorded{{Method: http.MethodGet, URI: "/perms/asset-permissions/?limit=2&offset=0&user_id=u1"}},
+		},
+		{
+			name:    "add relations with an empty id",
+			call:    func(p *AssetPermission) error { return p.AddNodes("", []string{"n1"}) },
+			wantErr: true,
+		},
+		{
+			name:    "empty id",
+			call:    func(p *AssetPermission) error { return p.Delete("") },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := tt.reply
+			if reply == "" {
+				reply = `{}`
+			}
+			var requests []recorded
+			api := newTestServer(t, reply, &requests)
+			err := tt.call(&AssetPermission{API: api})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(requests) != len(tt.want) {
+				t.Fatalf("sent %d requests %v, want %d %v", len(requests), requests, len(tt.want), tt.want)
+			}
+			for i, want := range tt.want {
+				if requests[i].Method != want.Method || requests[i].URI != want.URI {
+					t.Errorf("request %d = %s %s, want %s %s", i, requests[i].Method, requests[i].URI, want.Method, want.URI)
+				}
+				if !jsonEqual(requests[i].Body, want.Body) {
+					t.Errorf("request %d body = %s, want %s", i, requests[i].Body, want.Body)
+				}
+			}
+		})
+	}
+}
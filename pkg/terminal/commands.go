@@ -0,0 +1,94 @@
+package terminal
+
+import (
+	"context"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+)
+
+// Commands is a struct that holds configuration for the JmsAPI.
+// It is used to read the commands typed in sessions.
+type Commands struct {
+	API apiauth.JmsAPI
+}
+
+// CommandRep represents a command typed in a session together with its output.
+// Timestamp is the unix time of the command, RiskLevel tells whether it was accepted, warned or rejected.
+type CommandRep struct {
+	Id        string `json:"id"`
+	User      string `json:"user"`
+	Asset     string `json:"asset"`
+	Account   string `json:"account"`
+	Input     string `json:"input"`
+	Output    string `json:"output"`
+	Session   string `json:"session"`
+	RiskLevel struct {
+		Value int    `json:"value"`
+		Label string `json:"label"`
+	} `json:"risk_level"`
+	RemoteAddr       string `json:"remote_addr"`
+	Timestamp        int64  `json:"timestamp"`
+	TimestampDisplay string `json:"timestamp_display"`
+	OrgId            string `json:"org_id"`
+}
+
+// CommandListRep is the paginated response of the commands list endpoint.
+type CommandListRep = apiauth.ListRep[CommandRep]
+
+// List retrieves one page of the commands matching filter, or every command when filter sets no Limit.
+func (c *Commands) List(filter *CommandsFilter) (*CommandListRep, error) {
+	return c.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List; the request is cancelled together with ctx.
+func (c *Commands) ListWithContext(ctx context.Context, filter *CommandsFilter) (*CommandListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(c.API.GetEndpoint(), commandListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[CommandRep](ctx, c.API, endpoint, v)
+}
+
+// ListAll fetches every page of the commands matching filter and returns all of them.
+// A long session can hold tens of thousands of commands, prefer Iter to process them as they come.
+func (c *Commands) ListAll(filter *CommandsFilter) ([]CommandRep, error) {
+	return c.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll; every page request is cancelled together with ctx.
+func (c *Commands) ListAllWithContext(ctx context.Context, filter *CommandsFilter) ([]CommandRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(c.API.GetEndpoint(), commandListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[CommandRep](ctx, c.API, endpoint, v)
+}
+
+// Pages returns a pager over the commands matching filter.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (c *Commands) Pages(filter *CommandsFilter) *apiauth.Pager[CommandRep] {
+	return apiauth.NewQueryPager[CommandRep](c.API, utils.CombineURL(c.API.GetEndpoint(), commandListAPI), filter)
+}
+
+// Iter returns an iterator over every command matching filter, fetching the pages on demand.
+func (c *Commands) Iter(filter *CommandsFilter) *apiauth.Iterator[CommandRep] {
+	return apiauth.NewQueryIterator[CommandRep](c.API, utils.CombineURL(c.API.GetEndpoint(), commandListAPI), filter)
+}
+
+// SessionIter returns an iterator over every command of the session identified by sessionID.
+func (c *Commands) SessionIter(sessionID string) *apiauth.Iterator[CommandRep] {
+	return c.Iter(&CommandsFilter{Session: sessionID})
+}
@@ -1,6 +1,25 @@
 package terminal
 
 const (
-	sessionGetAPI  = "/terminal/sessions/%s/"
-	sessionListAPI = "/terminal/sessions/"
+	sessionGetAPI         = "/terminal/sessions/%s/"
+	sessionListAPI        = "/terminal/sessions/"
+	sessionReplayAPI      = "/terminal/sessions/%s/replay/"
+	sessionSharingAPI     = "/terminal/session-sharings/"
+	commandListAPI        = "/terminal/commands/"
+	terminalGetAPI        = "/terminal/terminals/%s/"
+	terminalListAPI       = "/terminal/terminals/"
+	terminalStatusListAPI = "/terminal/status/"
+	commandStorageGetAPI  = "/terminal/command-storages/%s/"
+	commandStorageListAPI = "/terminal/command-storages/"
+	commandStorageTestAPI = "/terminal/command-storages/%s/test-connective/"
+	replayStorageGetAPI   = "/terminal/replay-storages/%s/"
+	replayStorageListAPI  = "/terminal/replay-storages/"
+	replayStorageTestAPI  = "/terminal/replay-storages/%s/test-connective/"
+	taskGetAPI            = "/terminal/tasks/%s/"
+	taskListAPI           = "/terminal/tasks/"
+	taskKillSessionAPI    = "/terminal/tasks/kill-session/"
+	taskToggleLockAPI     = "/terminal/tasks/toggle-lock-session/"
+	taskNameKillSession   = "kill_session"
+	taskNameLockSession   = "lock_session"
+	taskNameUnlockSession = "unlock_session"
 )
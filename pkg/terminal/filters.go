@@ -30,3 +30,74 @@ type SessionsFilter struct {
 	DateFrom   string `url:"date_from"`
 	DateTo     string `url:"date_to"`
 }
+
+// CommandsFilter represents the filters that can be applied when querying the commands of sessions.
+// Session: Filter commands by session id.
+// Asset, Account, User: Filter commands by asset, account and user.
+// Input: Filter commands by input.
+// RiskLevel: Filter commands by risk level, for example 0 for accepted or 5 for rejected commands.
+// DateFrom, DateTo: Bound the command time, for example '2024-01-01T00:00:00Z'.
+// CommandStorageID: Read commands from the given command storage instead of the default one.
+type CommandsFilter struct {
+	Session          string `url:"session_id,omitempty"`
+	Asset            string `url:"asset,omitempty"`
+	Account          string `url:"account,omitempty"`
+	User             string `url:"user,omitempty"`
+	Input            string `url:"input,omitempty"`
+	RiskLevel        string `url:"risk_level,omitempty"`
+	DateFrom         string `url:"date_from,omitempty"`
+	DateTo           string `url:"date_to,omitempty"`
+	CommandStorageID string `url:"command_storage_id,omitempty"`
+	Order            string `url:"order,omitempty"`
+	Limit            int    `url:"limit,omitempty"`
+	Offset           int    `url:"offset,omitempty"`
+}
+
+// TasksFilter represents the filters that can be applied when querying the tasks sent to the terminal components.
+// Name: Filter tasks by name, for example 'kill_session', 'lock_session' or 'unlock_session'.
+// Args: Filter tasks by arguments, the session id for the session tasks.
+// IsFinished: Filter tasks by finish status.
+type TasksFilter struct {
+	Name       string `url:"name,omitempty"`
+	Args       string `url:"args,omitempty"`
+	IsFinished string `url:"is_finished,omitempty"`
+	Order      string `url:"order,omitempty"`
+	Limit      int    `url:"limit,omitempty"`
+	Offset     int    `url:"offset,omitempty"`
+}
+
+// TerminalsFilter represents the filters that can be applied when querying the registered terminal components.
+// Name, RemoteAddr: Filter components by name and address.
+// Type: Filter components by type, for example 'koko', 'lion', 'chen' or 'razor'.
+// IsActive: Filter components by enabled status.
+type TerminalsFilter struct {
+	Name       string `url:"name,omitempty"`
+	RemoteAddr string `url:"remote_addr,omitempty"`
+	Type       string `url:"type,omitempty"`
+	IsActive   string `url:"is_active,omitempty"`
+	Search     string `url:"search,omitempty"`
+	Order      string `url:"order,omitempty"`
+	Limit      int    `url:"limit,omitempty"`
+	Offset     int    `url:"offset,omitempty"`
+}
+
+// TerminalStatusFilter represents the filters that can be applied when querying the status reports of the components.
+// Terminal: Filter reports by component id.
+type TerminalStatusFilter struct {
+	Terminal string `url:"terminal,omitempty"`
+	Order    string `url:"order,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+	Offset   int    `url:"offset,omitempty"`
+}
+
+// StoragesFilter represents the filters that can be applied when querying the command or the replay storages.
+// Name: Filter storages by name.
+// Type: Filter storages by type, one of the StorageTypeXxx constants.
+type StoragesFilter struct {
+	Name   string `url:"name,omitempty"`
+	Type   string `url:"type,omitempty"`
+	Search string `url:"search,omitempty"`
+	Order  string `url:"order,omitempty"`
+	Limit  int    `url:"limit,omitempty"`
+	Offset int    `url:"offset,omitempty"`
+}
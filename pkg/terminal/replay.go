@@ -0,0 +1,281 @@
+package terminal
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+const (
+	// ReplayTypeAsciicast is the type of the asciicast v2 recordings of the terminal sessions.
+	ReplayTypeAsciicast = "asciicast"
+	// ReplayTypeJSON is the type of the legacy JSON recordings of the terminal sessions.
+	ReplayTypeJSON = "json"
+	// ReplayTypeGuacamole is the type of the guacamole recordings of the RDP and VNC sessions.
+	ReplayTypeGuacamole = "guacamole"
+	// ReplayTypeMP4 is the type of the video recordings of the remote application sessions.
+	ReplayTypeMP4 = "mp4"
+)
+
+// ReplayInfoRep represents the replay of a session as resolved by the server.
+// Type is one of the ReplayTypeXxx constants and Src the URL of the recording, either relative to
+// the JumpServer host or pointing to the object storage.
+type ReplayInfoRep struct {
+	Type        string `json:"type"`
+	Src         string `json:"src"`
+	User        string `json:"user"`
+	Asset       string `json:"asset"`
+	Account     string `json:"account"`
+	DateStart   string `json:"date_start"`
+	DateEnd     string `json:"date_end"`
+	DownloadURL string `json:"download_url"`
+}
+
+// Replay is the downloaded recording of a session. Reading it returns the recording content,
+// already decompressed when it was stored gzipped. It must be closed once read.
+type Replay struct {
+	Info ReplayInfoRep
+	io.Reader
+	closer io.Closer
+}
+
+// Close closes the underlying download.
+func (r *Replay) Close() error {
+	return r.closer.Close()
+}
+
+// Asciicast returns a reader decoding the replay as an asciicast v2 recording.
+func (r *Replay) Asciicast() (*AsciicastReader, error) {
+	if r.Info.Type != ReplayTypeAsciicast {
+		return nil, fmt.Errorf("replay type %s is not %s", r.Info.Type, ReplayTypeAsciicast)
+	}
+	return NewAsciicastReader(r)
+}
+
+// JSONEvents decodes the replay as a legacy JSON recording and returns its events ordered by time.
+func (r *Replay) JSONEvents() ([]AsciicastEvent, error) {
+	if r.Info.Type != ReplayTypeJSON {
+		return nil, fmt.Errorf("replay type %s is not %s", r.Info.Type, ReplayTypeJSON)
+	}
+	return DecodeJSONReplay(r)
+}
+
+// AsciicastHeader is the first line of an asciicast v2 recording.
+type AsciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title"`
+	Env       map[string]string `json:"env"`
+}
+
+// AsciicastEvent is an event of a recording.
+// Time is the number of seconds since the start of the session, Type is 'o' for the output
+// and 'i' for the input, and Data holds the printed or typed text.
+type AsciicastEvent struct {
+	Time float64
+	Type string
+	Data string
+}
+
+// AsciicastReader decodes an asciicast v2 recording event by event.
+type AsciicastReader struct {
+	Header  AsciicastHeader
+	scanner *bufio.Scanner
+}
+
+// NewAsciicastReader reads the header of the asciicast v2 recording r and returns a reader of its events.
+func NewAsciicastReader(r io.Reader) (*AsciicastReader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	// read header
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("asciicast header not found")
+	}
+	a := &AsciicastReader{scanner: scanner}
+	if err := json.Unmarshal(scanner.Bytes(), &a.Header); err != nil {
+		return nil, fmt.Errorf("decode asciicast header error: %s", err)
+	}
+	return a, nil
+}
+
+// Next returns the next event of the recording, or io.EOF once every event has been read.
+func (a *AsciicastReader) Next() (*AsciicastEvent, error) {
+	for a.scanner.Scan() {
+		line := a.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		// decode [time, type, data] event
+		var raw []interface{}
+		if err := json.Unmarshal(line, &raw); err != nil {
+			return nil, fmt.Errorf("decode asciicast event error: %s", err)
+		}
+		if len(raw) != 3 {
+			return nil, fmt.Errorf("invalid asciicast event: %s", line)
+		}
+		t, ok1 := raw[0].(float64)
+		typ, ok2 := raw[1].(string)
+		data, ok3 := raw[2].(string)
+		if !ok1 || !ok2 || !ok3 {
+			return nil, fmt.Errorf("invalid asciicast event: %s", line)
+		}
+		return &AsciicastEvent{Time: t, Type: typ, Data: data}, nil
+	}
+	if err := a.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// DecodeJSONReplay decodes a legacy JSON recording, an object mapping the time of each output
+// to its data, and returns its events ordered by time.
+func DecodeJSONReplay(r io.Reader) ([]AsciicastEvent, error) {
+	content := make(map[string]string)
+	if err := json.NewDecoder(r).Decode(&content); err != nil {
+		return nil, fmt.Errorf("decode json replay error: %s", err)
+	}
+	events := make([]AsciicastEvent, 0, len(content))
+	for k, data := range content {
+		t, err := strconv.ParseFloat(k, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid json replay time: %s", k)
+		}
+		events = append(events, AsciicastEvent{Time: t, Type: "o", Data: data})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Time < events[j].Time })
+	return events, nil
+}
+
+// ReplayInfo resolves the replay of the session identified by id without downloading it.
+// The server answers with a not found error while the recording is not uploaded yet.
+func (s *Sessions) ReplayInfo(id string) (*ReplayInfoRep, error) {
+	return s.ReplayInfoWithContext(context.Background(), id)
+}
+
+// ReplayInfoWithContext is like ReplayInfo; the request is cancelled together with ctx.
+func (s *Sessions) ReplayInfoWithContext(ctx context.Context, id string) (*ReplayInfoRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("session id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(s.API.GetEndpoint(), fmt.Sprintf(sessionReplayAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, s.API, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	data := &ReplayInfoRep{}
+	err = s.API.DoRequest(req, data)
+	return data, err
+}
+
+// Replay resolves and downloads the replay of the session identified by id.
+// Redirects to the object storage are followed and a gzipped recording is transparently decompressed.
+// The caller must close the returned Replay.
+func (s *Sessions) Replay(id string) (*Replay, error) {
+	return s.ReplayWithContext(context.Background(), id)
+}
+
+// ReplayWithContext is like Replay; the download is cancelled together with ctx.
+func (s *Sessions) ReplayWithContext(ctx context.Context, id string) (*Replay, error) {
+	info, err := s.ReplayInfoWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if info.Src == "" {
+		return nil, fmt.Errorf("session %s has no replay", id)
+	}
+
+	// resolve src against the api host
+	base, err := url.Parse(s.API.GetEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	src, err := base.Parse(info.Src)
+	if err != nil {
+		return nil, err
+	}
+
+	// download replay
+	body, err := s.download(ctx, base, src)
+	if err != nil {
+		return nil, err
+	}
+
+	// decompress gzipped content
+	buffered := bufio.NewReader(body)
+	var reader io.Reader = buffered
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			body.Close()
+			return nil, fmt.Errorf("decompress replay error: %s", err)
+		}
+		reader = gz
+	}
+	return &Replay{Info: *info, Reader: reader, closer: body}, nil
+}
+
+// download gets src, through the api when it is on the JumpServer host and with the plain
+// shared client otherwise, since pre-signed object storage URLs reject any other authentication.
+func (s *Sessions) download(ctx context.Context, base, src *url.URL) (io.ReadCloser, error) {
+	if src.Host == base.Host {
+		req, err := apiauth.MakeRequestWithContext(ctx, s.API, http.MethodGet, src.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := apiauth.DoRawRequest(s.API, req)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	}
+
+	// get shared client
+	client := http.DefaultClient
+	if c, ok := s.API.(interface{ Client() (*http.Client, error) }); ok {
+		shared, err := c.Client()
+		if err != nil {
+			return nil, err
+		}
+		client = shared
+	}
+
+	// do request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, apiauth.NewAPIError(resp, body)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,170 @@
+package terminal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAsciicastReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		width   int
+		events  []AsciicastEvent
+		wantErr bool
+	}{
+		{
+			name:    "events",
+			content: "{\"version\":2,\"width\":80,\"height\":24}\n[0.5,\"o\",\"$ \"]\n\n[1.25,\"i\",\"ls\\r\"]\n",
+			width:   80,
+			events:  []AsciicastEvent{{Time: 0.5, Type: "o", Data: "$ "}, {Time: 1.25, Type: "i", Data: "ls\r"}},
+		},
+		{name: "header only", content: "{\"version\":2,\"width\":120}", width: 120},
+		{name: "invalid event", content: "{\"version\":2}\n[0.5,\"o\"]\n", wantErr: true},
+		{name: "invalid event types", content: "{\"version\":2}\n[\"0.5\",\"o\",\"x\"]\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewAsciicastReader(strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("NewAsciicastReader() error = %v", err)
+			}
+			if reader.Header.Width != tt.width {
+				t.Errorf("NewAsciicastReader() width = %d, want %d", reader.Header.Width, tt.width)
+			}
+			var events []AsciicastEvent
+			for {
+				event, err := reader.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					if !tt.wantErr {
+						t.Errorf("Next() error = %v", err)
+					}
+					return
+				}
+				events = append(events, *event)
+			}
+			if tt.wantErr {
+				t.Fatalf("Next() error = nil, want an error")
+			}
+			if !reflect.DeepEqual(events, tt.events) {
+				t.Errorf("Next() events = %v, want %v", events, tt.events)
+			}
+		})
+	}
+
+	if _, err := NewAsciicastReader(strings.NewReader("")); err == nil {
+		t.Errorf("NewAsciicastReader() of an empty recording error = nil, want an error")
+	}
+}
+
+func TestDecodeJSONReplay(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		events  []AsciicastEvent
+		wantErr bool
+	}{
+		{
+			name:    "ordered by time",
+			content: `{"10.5":"c","2":"b","0.1":"a"}`,
+			events:  []AsciicastEvent{{Time: 0.1, Type: "o", Data: "a"}, {Time: 2, Type: "o", Data: "b"}, {Time: 10.5, Type: "o", Data: "c"}},
+		},
+		{name: "empty", content: `{}`, events: []AsciicastEvent{}},
+		{name: "invalid time", content: `{"start":"a"}`, wantErr: true},
+		{name: "not an object", content: `["a"]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := DecodeJSONReplay(strings.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeJSONReplay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(events, tt.events) {
+				t.Errorf("DecodeJSONReplay() = %v, want %v", events, tt.events)
+			}
+		})
+	}
+}
+
+func TestSessionsReplay(t *testing.T) {
+	const recording = "{\"version\":2,\"width\":80}\n[0.5,\"o\",\"$ \"]\n"
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write([]byte(recording))
+	gz.Close()
+
+	tests := []struct {
+		name      string
+		offHost   bool
+		status    int
+		content   []byte
+		wantErr   func(error) bool
+		wantEvent bool
+	}{
+		{name: "on host", status: http.StatusOK, content: []byte(recording), wantEvent: true},
+		{name: "on host gzipped", status: http.StatusOK, content: gzipped.Bytes(), wantEvent: true},
+		{name: "object storage gzipped", offHost: true, status: http.StatusOK, content: gzipped.Bytes(), wantEvent: true},
+		{name: "object storage denied", offHost: true, status: http.StatusForbidden, content: []byte("<Error>AccessDenied</Error>"),
+			wantErr: apiauth.IsPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "" {
+					t.Errorf("object storage request carries the JumpServer authorization")
+				}
+				w.WriteHeader(tt.status)
+				w.Write(tt.content)
+			}))
+			defer storage.Close()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/terminal/sessions/s1/replay/":
+					src := "/media/replay/s1.cast.gz"
+					if tt.offHost {
+						src = storage.URL + "/bucket/s1.cast.gz?signature=x"
+					}
+					fmt.Fprintf(w, `{"type":"asciicast","src":%q}`, src)
+				case "/media/replay/s1.cast.gz":
+					w.WriteHeader(tt.status)
+					w.Write(tt.content)
+				default:
+					t.Errorf("request sent to %s", r.URL.Path)
+				}
+			}))
+			defer srv.Close()
+
+			sessions := &Sessions{API: &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}}
+			replay, err := sessions.Replay("s1")
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("Replay() error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			defer replay.Close()
+			reader, err := replay.Asciicast()
+			if err != nil {
+				t.Fatalf("Asciicast() error = %v", err)
+			}
+			event, err := reader.Next()
+			if err != nil || event.Data != "$ " {
+				t.Errorf("Next() = %+v, %v, want the first event", event, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package terminal
 
 import (
+	"context"
 	"fmt"
 	"github.com/MScuti/gojms/pkg/apiauth"
 	"github.com/MScuti/gojms/pkg/utils"
@@ -65,7 +66,7 @@ type SessionDetailRep struct {
 // the URL for the next page,
 // the URL for the previous page,
 // and a list of detailed session representations.
-type SessionListRep []SessionDetailRep
+type SessionListRep = apiauth.ListRep[SessionDetailRep]
 
 // Get is a method on the Sessions struct.
 // It accepts a string id as a parameter and retrieves the session details
@@ -76,6 +77,11 @@ type SessionListRep []SessionDetailRep
 // If the retrieval and unmarshalling are successful, it returns the SessionDetailRep
 // object along with a nil error. Otherwise, it returns nil and the associated error.
 func (s *Sessions) Get(id string) (*SessionDetailRep, error) {
+	return s.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get; the request is cancelled together with ctx.
+func (s *Sessions) GetWithContext(ctx context.Context, id string) (*SessionDetailRep, error) {
 	// check id
 	if id == "" {
 		return nil, fmt.Errorf("session id can not empty")
@@ -86,7 +92,7 @@ func (s *Sessions) Get(id string) (*SessionDetailRep, error) {
 	endpoint = fmt.Sprintf(endpoint, id)
 
 	// make request
-	req, err := s.API.MakeRequest(http.MethodGet, endpoint, nil)
+	req, err := apiauth.MakeRequestWithContext(ctx, s.API, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -106,26 +112,218 @@ func (s *Sessions) Get(id string) (*SessionDetailRep, error) {
 // If the operations are successful, it returns a pointer to the SessionListRep object and a nil error.
 // If there's an error during these operations, it returns nil and the error.
 func (s *Sessions) List(filter *SessionsFilter) (*SessionListRep, error) {
+	return s.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List; the request is cancelled together with ctx.
+func (s *Sessions) ListWithContext(ctx context.Context, filter *SessionsFilter) (*SessionListRep, error) {
 	// combine api endpoint
 	endpoint := utils.CombineURL(s.API.GetEndpoint(), sessionListAPI)
 
-	// make request
-	req, err := s.API.MakeRequest(http.MethodGet, endpoint, nil)
+	// set query params
+	v, err := query.Values(filter)
 	if err != nil {
 		return nil, err
 	}
 
+	// do request
+	return apiauth.List[SessionDetailRep](ctx, s.API, endpoint, v)
+}
+
+// ListAll fetches every page of the sessions matching filter and returns all of them.
+// The Limit of filter is used as page size, apiauth.DefaultPageSize when it is not set.
+func (s *Sessions) ListAll(filter *SessionsFilter) ([]SessionDetailRep, error) {
+	return s.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (s *Sessions) ListAllWithContext(ctx context.Context, filter *SessionsFilter) ([]SessionDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(s.API.GetEndpoint(), sessionListAPI)
+
 	// set query params
-	if filter != nil {
-		v, err := query.Values(filter)
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[SessionDetailRep](ctx, s.API, endpoint, v)
+}
+
+// Pages returns a pager over the sessions matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (s *Sessions) Pages(filter *SessionsFilter) *apiauth.Pager[SessionDetailRep] {
+	return apiauth.NewQueryPager[SessionDetailRep](s.API, utils.CombineURL(s.API.GetEndpoint(), sessionListAPI), filter)
+}
+
+// Iter returns an iterator over every session matching filter, fetching the pages on demand.
+func (s *Sessions) Iter(filter *SessionsFilter) *apiauth.Iterator[SessionDetailRep] {
+	return apiauth.NewQueryIterator[SessionDetailRep](s.API, utils.CombineURL(s.API.GetEndpoint(), sessionListAPI), filter)
+}
+
+// SessionSharingReq is the request body used to share a session with other users.
+// ExpiredTime is the validity of the sharing in minutes, Users the ids of the users allowed to join,
+// every user when empty, and ActionPermission is 'writable' or 'readonly'.
+type SessionSharingReq struct {
+	Session          string   `json:"session"`
+	ExpiredTime      int      `json:"expired_time"`
+	Users            []string `json:"users,omitempty"`
+	ActionPermission string   `json:"action_permission,omitempty"`
+}
+
+// SessionSharingRep represents the sharing of a session.
+// A user joins the session with the sharing id and the VerifyCode.
+type SessionSharingRep struct {
+	Id               string   `json:"id"`
+	Session          string   `json:"session"`
+	VerifyCode       string   `json:"verify_code"`
+	IsActive         bool     `json:"is_active"`
+	ExpiredTime      int      `json:"expired_time"`
+	Users            []string `json:"users"`
+	ActionPermission string   `json:"action_permission"`
+	Creator          string   `json:"creator"`
+	OrgId            string   `json:"org_id"`
+	DateCreated      string   `json:"date_created"`
+}
+
+// taskSessionsRep is the response of the session task endpoints, listing the accepted session ids.
+type taskSessionsRep struct {
+	Ok []string `json:"ok"`
+}
+
+// Terminate asks the terminal components to kill the sessions identified by ids.
+// It returns the created kill tasks, one per session accepted by the server, which can be polled
+// with Tasks.Get until they are finished. Sessions already finished are not accepted.
+// When a kill task can not be read back the sessions are still being killed, the tasks found are
+// returned together with a *TaskLookupError listing the accepted sessions.
+func (s *Sessions) Terminate(ids ...string) ([]TaskRep, error) {
+	return s.TerminateWithContext(context.Background(), ids...)
+}
+
+// TerminateWithContext is like Terminate; the requests are cancelled together with ctx.
+func (s *Sessions) TerminateWithContext(ctx context.Context, ids ...string) ([]TaskRep, error) {
+	// check ids
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("session ids can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(s.API.GetEndpoint(), taskKillSessionAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, s.API, http.MethodPost, endpoint, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	data := &taskSessionsRep{}
+	if err = s.API.DoRequest(req, data); err != nil {
+		return nil, err
+	}
+	return s.tasks(ctx, taskNameKillSession, data.Ok)
+}
+
+// Lock locks the session identified by id, the user can no longer type until it is unlocked.
+// It returns the created lock task, or a *TaskLookupError when the accepted task can not be read back.
+func (s *Sessions) Lock(id string) (*TaskRep, error) {
+	return s.LockWithContext(context.Background(), id)
+}
+
+// LockWithContext is like Lock; the requests are cancelled together with ctx.
+func (s *Sessions) LockWithContext(ctx context.Context, id string) (*TaskRep, error) {
+	return s.toggleLock(ctx, taskNameLockSession, id)
+}
+
+// Unlock unlocks the session identified by id. It returns the created unlock task, or a *TaskLookupError
+// when the accepted task can not be read back.
+func (s *Sessions) Unlock(id string) (*TaskRep, error) {
+	return s.UnlockWithContext(context.Background(), id)
+}
+
+// UnlockWithContext is like Unlock; the requests are cancelled together with ctx.
+func (s *Sessions) UnlockWithContext(ctx context.Context, id string) (*TaskRep, error) {
+	return s.toggleLock(ctx, taskNameUnlockSession, id)
+}
+
+// toggleLock sends the lock or unlock task named name for the session identified by id.
+func (s *Sessions) toggleLock(ctx context.Context, name, id string) (*TaskRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("session id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(s.API.GetEndpoint(), taskToggleLockAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, s.API, http.MethodPost, endpoint, map[string]string{
+		"session_id": id,
+		"task_name":  name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	if err = s.API.DoRequest(req, nil); err != nil {
+		return nil, err
+	}
+	tasks := Tasks{API: s.API}
+	task, err := tasks.latest(ctx, name, id)
+	if err != nil {
+		return nil, &TaskLookupError{Accepted: []string{id}, Missing: []string{id}, Err: err}
+	}
+	return task, nil
+}
+
+// tasks returns the latest task named name of each session of ids, the sessions accepted by the server.
+// A failed lookup does not stop the others, the tasks found are returned with a *TaskLookupError.
+func (s *Sessions) tasks(ctx context.Context, name string, ids []string) ([]TaskRep, error) {
+	tasks := Tasks{API: s.API}
+	result := make([]TaskRep, 0, len(ids))
+	var lookupErr *TaskLookupError
+	for _, id := range ids {
+		task, err := tasks.latest(ctx, name, id)
 		if err != nil {
-			return nil, err
+			if lookupErr == nil {
+				lookupErr = &TaskLookupError{Accepted: ids, Err: err}
+			}
+			lookupErr.Missing = append(lookupErr.Missing, id)
+			continue
 		}
-		req = s.API.SetQuery(req, v)
+		result = append(result, *task)
+	}
+	if lookupErr != nil {
+		return result, lookupErr
+	}
+	return result, nil
+}
+
+// Share creates a sharing of a session, which other users can use to join it.
+func (s *Sessions) Share(data *SessionSharingReq) (*SessionSharingRep, error) {
+	return s.ShareWithContext(context.Background(), data)
+}
+
+// ShareWithContext is like Share; the request is cancelled together with ctx.
+func (s *Sessions) ShareWithContext(ctx context.Context, data *SessionSharingReq) (*SessionSharingRep, error) {
+	// check session
+	if data == nil || data.Session == "" {
+		return nil, fmt.Errorf("session id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(s.API.GetEndpoint(), sessionSharingAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, s.API, http.MethodPost, endpoint, data)
+	if err != nil {
+		return nil, err
 	}
 
 	// do request
-	data := &SessionListRep{}
-	err = s.API.DoRequest(req, data)
-	return data, err
+	rep := &SessionSharingRep{}
+	err = s.API.DoRequest(req, rep)
+	return rep, err
 }
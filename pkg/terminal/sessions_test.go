@@ -0,0 +1,131 @@
+package terminal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// taskServer serves the session task endpoints. accepted is the answer of the kill endpoint and tasks maps
+// a session id to the latest task listed for it, a session without task gets an empty list.
+func taskServer(t *testing.T, accepted []string, tasks map[string]TaskRep) apiauth.JmsAPI {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/terminal/tasks/kill-session/":
+			json.NewEncoder(w).Encode(map[string][]string{"ok": accepted})
+		case "/terminal/tasks/toggle-lock-session/":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"session_id":"s1","task_name":"lock_session"}` {
+				t.Errorf("toggle lock body = %s", body)
+			}
+			w.Write([]byte(`{"ok":true}`))
+		case "/terminal/tasks/":
+			q := r.URL.Query()
+			if q.Get("order") != "-date_created" || q.Get("limit") != "1" {
+				t.Errorf("tasks query = %v, want the latest task", q)
+			}
+			results := []TaskRep{}
+			if task, ok := tasks[q.Get("args")]; ok {
+				results = append(results, task)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "next": nil, "results": results})
+		default:
+			t.Errorf("request sent to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+}
+
+func TestSessionsTerminate(t *testing.T) {
+	kill := func(id string) TaskRep { return TaskRep{Id: "t-" + id, Name: "kill_session", Args: id} }
+	tests := []struct {
+		name     string
+		accepted []string
+		tasks    map[string]TaskRep
+		want     []TaskRep
+		missing  []string
+	}{
+		{
+			name:     "every task found",
+			accepted: []string{"s1", "s2"},
+			tasks:    map[string]TaskRep{"s1": kill("s1"), "s2": kill("s2")},
+			want:     []TaskRep{kill("s1"), kill("s2")},
+		},
+		{
+			name:     "finished session not accepted",
+			accepted: []string{"s1"},
+			tasks:    map[string]TaskRep{"s1": kill("s1"), "s2": kill("s2")},
+			want:     []TaskRep{kill("s1")},
+		},
+		{
+			name:     "task not found",
+			accepted: []string{"s1", "s2"},
+			tasks:    map[string]TaskRep{"s1": kill("s1")},
+			want:     []TaskRep{kill("s1")},
+			missing:  []string{"s2"},
+		},
+		{
+			name:     "task of another session",
+			accepted: []string{"s1", "s2"},
+			tasks:    map[string]TaskRep{"s1": kill("s1"), "s2": {Id: "t-x", Name: "kill_session", Args: "s3"}},
+			want:     []TaskRep{kill("s1")},
+			missing:  []string{"s2"},
+		},
+		{
+			name:     "task of another name",
+			accepted: []string{"s1"},
+			tasks:    map[string]TaskRep{"s1": {Id: "t-x", Name: "lock_session", Args: "s1"}},
+			want:     []TaskRep{},
+			missing:  []string{"s1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions := &Sessions{API: taskServer(t, tt.accepted, tt.tasks)}
+			got, err := sessions.Terminate("s1", "s2")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Terminate() = %+v, want %+v", got, tt.want)
+			}
+			var lookupErr *TaskLookupError
+			switch {
+			case tt.missing == nil && err != nil:
+				t.Errorf("Terminate() error = %v", err)
+			case tt.missing != nil && !errors.As(err, &lookupErr):
+				t.Errorf("Terminate() error = %v, want a *TaskLookupError", err)
+			case tt.missing != nil:
+				if !reflect.DeepEqual(lookupErr.Missing, tt.missing) || !reflect.DeepEqual(lookupErr.Accepted, tt.accepted) {
+					t.Errorf("Terminate() error = %+v, want missing %v of accepted %v", lookupErr, tt.missing, tt.accepted)
+				}
+			}
+		})
+	}
+
+	if _, err := (&Sessions{}).Terminate(); err == nil {
+		t.Errorf("Terminate() without id error = nil, want an error")
+	}
+}
+
+func TestSessionsLock(t *testing.T) {
+	lock := TaskRep{Id: "t-s1", Name: "lock_session", Args: "s1"}
+	sessions := &Sessions{API: taskServer(t, nil, map[string]TaskRep{"s1": lock})}
+	task, err := sessions.Lock("s1")
+	if err != nil || !reflect.DeepEqual(*task, lock) {
+		t.Errorf("Lock() = %+v, %v, want %+v", task, err, lock)
+	}
+
+	// the server accepted the lock but the task listed is an older unlock
+	sessions = &Sessions{API: taskServer(t, nil, map[string]TaskRep{"s1": {Id: "t-old", Name: "unlock_session", Args: "s1"}})}
+	var lookupErr *TaskLookupError
+	if _, err := sessions.Lock("s1"); !errors.As(err, &lookupErr) || fmt.Sprint(lookupErr.Accepted) != "[s1]" {
+		t.Errorf("Lock() error = %v, want a *TaskLookupError of s1", err)
+	}
+}
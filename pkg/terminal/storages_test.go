@@ -0,0 +1,57 @@
+package terminal
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReplayStorageCreateSecretKey(t *testing.T) {
+	const key = "s3-secret-key"
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/terminal/replay-storages/" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		body, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{"id":"r1","type":{"value":"s3","label":"S3"},"meta":{"BUCKET":"replays","ACCESS_KEY":"ak","ENDPOINT":"https://s3"}}`))
+	}))
+	defer srv.Close()
+
+	meta := &S3Meta{Bucket: "replays", AccessKey: "ak", SecretKey: utils.NewSecret([]byte(key)), Endpoint: "https://s3"}
+	for _, verb := range []string{"%v", "%+v", "%#v"} {
+		if out := fmt.Sprintf(verb, meta); strings.Contains(out, key) {
+			t.Errorf("%s prints the secret key: %s", verb, out)
+		}
+	}
+	var logs bytes.Buffer
+	slog.New(slog.NewTextHandler(&logs, nil)).Info("storage", "key", meta.SecretKey)
+	if strings.Contains(logs.String(), key) {
+		t.Errorf("slog prints the secret key: %s", logs.String())
+	}
+
+	r := &ReplayStorage{API: &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}}
+	rep, err := r.Create(&StorageReq{Name: "s3", Type: StorageTypeS3, Meta: meta})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(body), key); n != 1 || !strings.Contains(string(body), `"SECRET_KEY":"`+key+`"`) {
+		t.Errorf("body = %s, want the secret key once", body)
+	}
+
+	// the server does not return the secret key
+	got := &S3Meta{}
+	if err := rep.DecodeMeta(got); err != nil {
+		t.Fatal(err)
+	}
+	if got.SecretKey != nil || got.Bucket != "replays" {
+		t.Errorf("decoded meta = %+v", got)
+	}
+}
@@ -0,0 +1,115 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+)
+
+// Tasks is a struct that holds configuration for the JmsAPI.
+// It is used to read the tasks sent to the terminal components, such as killing or locking a session.
+type Tasks struct {
+	API apiauth.JmsAPI
+}
+
+// TaskRep represents a task sent to a terminal component.
+// Args holds the session id for the session tasks, IsFinished turns true once the component executed it.
+type TaskRep struct {
+	Id           string      `json:"id"`
+	Name         string      `json:"name"`
+	Args         string      `json:"args"`
+	Kwargs       interface{} `json:"kwargs"`
+	IsFinished   bool        `json:"is_finished"`
+	DateCreated  string      `json:"date_created"`
+	DateFinished string      `json:"date_finished"`
+}
+
+// TaskListRep is the paginated response of the tasks list endpoint.
+type TaskListRep = apiauth.ListRep[TaskRep]
+
+// Get retrieves the task identified by id, used to poll for its completion.
+func (t *Tasks) Get(id string) (*TaskRep, error) {
+	return t.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get; the request is cancelled together with ctx.
+func (t *Tasks) GetWithContext(ctx context.Context, id string) (*TaskRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("task id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), fmt.Sprintf(taskGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, t.API, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	data := &TaskRep{}
+	err = t.API.DoRequest(req, data)
+	return data, err
+}
+
+// List retrieves one page of the tasks matching filter, or every task when filter sets no Limit.
+func (t *Tasks) List(filter *TasksFilter) (*TaskListRep, error) {
+	return t.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List; the request is cancelled together with ctx.
+func (t *Tasks) ListWithContext(ctx context.Context, filter *TasksFilter) (*TaskListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), taskListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[TaskRep](ctx, t.API, endpoint, v)
+}
+
+// latest returns the most recent task named name for the session identified by sessionID.
+func (t *Tasks) latest(ctx context.Context, name, sessionID string) (*TaskRep, error) {
+	page, err := t.ListWithContext(ctx, &TasksFilter{Name: name, Args: sessionID, Order: "-date_created", Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Results) == 0 {
+		return nil, fmt.Errorf("%s task of session %s not found", name, sessionID)
+	}
+
+	// check the task really is the one of the session, in case the server ignored a filter
+	task := &page.Results[0]
+	if task.Name != name || task.Args != sessionID {
+		return nil, fmt.Errorf("%s task of session %s not found, latest task is %s %s", name, sessionID, task.Name, task.Args)
+	}
+	return task, nil
+}
+
+// TaskLookupError is returned when the server accepted a session task but the created task could not be
+// read back. The task was still sent to the terminal component: Accepted lists every session accepted by
+// the server and Missing the sessions whose task could not be found.
+type TaskLookupError struct {
+	Accepted []string
+	Missing  []string
+	Err      error
+}
+
+// Error implements the error interface.
+func (e *TaskLookupError) Error() string {
+	return fmt.Sprintf("task of sessions %v accepted but not found: %s", e.Missing, e.Err)
+}
+
+// Unwrap returns the error of the first failed lookup.
+func (e *TaskLookupError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,218 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+)
+
+// Terminals is a struct that holds configuration for the JmsAPI.
+// It is used to manage the registered terminal components (KoKo, Lion, Chen, Razor...) and read their health.
+type Terminals struct {
+	API apiauth.JmsAPI
+}
+
+// TerminalStat is the load reported by a terminal component.
+// CpuLoad is the load average, MemoryUsed and DiskUsed are percentages.
+type TerminalStat struct {
+	CpuLoad       float64 `json:"cpu_load"`
+	MemoryUsed    float64 `json:"memory_used"`
+	DiskUsed      float64 `json:"disk_used"`
+	SessionOnline int     `json:"session_online"`
+}
+
+// TerminalRep represents a registered terminal component.
+// IsAlive is true while the component keeps reporting its status, Load summarizes its Stat
+// ('normal', 'high', 'critical' or 'offline').
+type TerminalRep struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Type struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"type"`
+	Version       string       `json:"version"`
+	RemoteAddr    string       `json:"remote_addr"`
+	SessionOnline int          `json:"session_online"`
+	IsAlive       bool         `json:"is_alive"`
+	IsActive      bool         `json:"is_active"`
+	Stat          TerminalStat `json:"stat"`
+	Load          struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	} `json:"load"`
+	CommandStorage string `json:"command_storage"`
+	ReplayStorage  string `json:"replay_storage"`
+	Comment        string `json:"comment"`
+	DateCreated    string `json:"date_created"`
+}
+
+// TerminalListRep is the paginated response of the terminal components list endpoint.
+type TerminalListRep = apiauth.ListRep[TerminalRep]
+
+// TerminalStatusRep is a status report sent by a terminal component.
+type TerminalStatusRep struct {
+	Id string `json:"id"`
+	TerminalStat
+	Connections int    `json:"connections"`
+	Threads     int    `json:"threads"`
+	BootTime    int64  `json:"boot_time"`
+	Terminal    string `json:"terminal"`
+	DateCreated string `json:"date_created"`
+}
+
+// TerminalStatusListRep is the paginated response of the terminal status list endpoint.
+type TerminalStatusListRep = apiauth.ListRep[TerminalStatusRep]
+
+// TerminalPatchReq is the request body used to update a terminal component, only the non nil fields are sent.
+// CommandStorage and ReplayStorage are the names of the storages the component must use.
+type TerminalPatchReq struct {
+	Name           *string `json:"name,omitempty"`
+	IsActive       *bool   `json:"is_active,omitempty"`
+	CommandStorage *string `json:"command_storage,omitempty"`
+	ReplayStorage  *string `json:"replay_storage,omitempty"`
+	Comment        *string `json:"comment,omitempty"`
+}
+
+// Get retrieves the terminal component identified by id.
+func (t *Terminals) Get(id string) (*TerminalRep, error) {
+	return t.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get; the request is cancelled together with ctx.
+func (t *Terminals) GetWithContext(ctx context.Context, id string) (*TerminalRep, error) {
+	rep := &TerminalRep{}
+	err := t.send(ctx, http.MethodGet, id, nil, rep)
+	return rep, err
+}
+
+// List retrieves one page of the terminal components matching filter, or every component when filter sets no Limit.
+func (t *Terminals) List(filter *TerminalsFilter) (*TerminalListRep, error) {
+	return t.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List; the request is cancelled together with ctx.
+func (t *Terminals) ListWithContext(ctx context.Context, filter *TerminalsFilter) (*TerminalListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), terminalListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[TerminalRep](ctx, t.API, endpoint, v)
+}
+
+// ListAll fetches every page of the terminal components matching filter and returns all of them.
+func (t *Terminals) ListAll(filter *TerminalsFilter) ([]TerminalRep, error) {
+	return t.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll; every page request is cancelled together with ctx.
+func (t *Terminals) ListAllWithContext(ctx context.Context, filter *TerminalsFilter) ([]TerminalRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), terminalListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[TerminalRep](ctx, t.API, endpoint, v)
+}
+
+// Offline returns every registered terminal component that stopped reporting its status,
+// the candidates for garbage collection.
+func (t *Terminals) Offline() ([]TerminalRep, error) {
+	return t.OfflineWithContext(context.Background())
+}
+
+// OfflineWithContext is like Offline; every page request is cancelled together with ctx.
+func (t *Terminals) OfflineWithContext(ctx context.Context) ([]TerminalRep, error) {
+	terminals, err := t.ListAllWithContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	offline := make([]TerminalRep, 0)
+	for _, terminal := range terminals {
+		if !terminal.IsAlive {
+			offline = append(offline, terminal)
+		}
+	}
+	return offline, nil
+}
+
+// Update updates the non nil fields of data on the terminal component identified by id.
+func (t *Terminals) Update(id string, data *TerminalPatchReq) (*TerminalRep, error) {
+	return t.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is like Update; the request is cancelled together with ctx.
+func (t *Terminals) UpdateWithContext(ctx context.Context, id string, data *TerminalPatchReq) (*TerminalRep, error) {
+	rep := &TerminalRep{}
+	err := t.send(ctx, http.MethodPatch, id, data, rep)
+	return rep, err
+}
+
+// Delete deletes the registration of the terminal component identified by id.
+// A component still running registers again on its next start.
+func (t *Terminals) Delete(id string) error {
+	return t.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete; the request is cancelled together with ctx.
+func (t *Terminals) DeleteWithContext(ctx context.Context, id string) error {
+	return t.send(ctx, http.MethodDelete, id, nil, nil)
+}
+
+// send sends data with method to the detail endpoint of the terminal component identified by id
+// and decodes the response into result.
+func (t *Terminals) send(ctx context.Context, method, id string, data, result interface{}) error {
+	// check id
+	if id == "" {
+		return fmt.Errorf("terminal id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), fmt.Sprintf(terminalGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, t.API, method, endpoint, data)
+	if err != nil {
+		return err
+	}
+
+	// do request
+	return t.API.DoRequest(req, result)
+}
+
+// Status retrieves one page of the status reports matching filter, newest first unless filter sets an Order.
+func (t *Terminals) Status(filter *TerminalStatusFilter) (*TerminalStatusListRep, error) {
+	return t.StatusWithContext(context.Background(), filter)
+}
+
+// StatusWithContext is like Status; the request is cancelled together with ctx.
+func (t *Terminals) StatusWithContext(ctx context.Context, filter *TerminalStatusFilter) (*TerminalStatusListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(t.API.GetEndpoint(), terminalStatusListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+	if v.Get("order") == "" {
+		v.Set("order", "-date_created")
+	}
+
+	// do request
+	return apiauth.List[TerminalStatusRep](ctx, t.API, endpoint, v)
+}
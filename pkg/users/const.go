@@ -1,7 +1,14 @@
 package users
 
 const (
-	userGetAPI    = "/users/users/%s/"
-	userListAPI   = "/users/users/"
-	userAssetsAPI = "/perms/users/%s/assets/"
+	userGetAPI           = "/users/users/%s/"
+	userListAPI          = "/users/users/"
+	userAssetsAPI        = "/perms/users/%s/assets/"
+	userMFAResetAPI      = "/users/users/%s/mfa/reset/"
+	userUnblockAPI       = "/users/users/%s/unblock/"
+	userPasswordResetAPI = "/users/users/%s/password/reset/"
+
+	groupGetAPI       = "/users/groups/%s/"
+	groupListAPI      = "/users/groups/"
+	groupRelationsAPI = "/users/users-groups-relations/"
 )
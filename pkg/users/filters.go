@@ -24,3 +24,15 @@ type UserFilter struct {
 	Limit          int    `url:"limit,omitempty"`
 	Offset         int    `url:"offset,omitempty"`
 }
+
+// GroupFilter is a struct that represents the filtering options for querying user groups.
+// The `url` tag specifies the URL query string parameter name associated with the struct field,
+// empty fields are not sent.
+type GroupFilter struct {
+	ID     string `url:"id,omitempty"`
+	Name   string `url:"name,omitempty"`
+	Search string `url:"search,omitempty"`
+	Order  string `url:"order,omitempty"`
+	Limit  int    `url:"limit,omitempty"`
+	Offset int    `url:"offset,omitempty"`
+}
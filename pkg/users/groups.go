@@ -0,0 +1,246 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"github.com/MScuti/gojms/pkg/utils"
+	"github.com/google/go-querystring/query"
+	"net/http"
+	"net/url"
+)
+
+// The Group struct holds the configuration for the JmsAPI.
+// It is used to manage user groups and their members.
+type Group struct {
+	API apiauth.JmsAPI
+}
+
+// GroupDetailRep represents a user group.
+// Users holds the members of the group, UsersAmount their number.
+type GroupDetailRep struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Comment string `json:"comment"`
+	Users   []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"users"`
+	UsersAmount int           `json:"users_amount"`
+	Labels      []interface{} `json:"labels"`
+	OrgId       string        `json:"org_id"`
+	OrgName     string        `json:"org_name"`
+	CreatedBy   string        `json:"created_by"`
+	DateCreated string        `json:"date_created"`
+}
+
+// GroupListRep is the paginated response of the user groups list endpoint.
+type GroupListRep = apiauth.ListRep[GroupDetailRep]
+
+// GroupReq is the request body used to create or update a user group.
+// Users holds the ids of the members and replaces the current members on update, a pointer to an empty
+// slice removes every member. When Users is nil the members are left unchanged.
+type GroupReq struct {
+	Name    string    `json:"name"`
+	Comment string    `json:"comment,omitempty"`
+	Users   *[]string `json:"users,omitempty"`
+}
+
+// groupRelation is a user to group membership of the relations endpoint.
+type groupRelation struct {
+	User      string `json:"user"`
+	UserGroup string `json:"usergroup"`
+}
+
+// Get retrieves the user group identified by id.
+func (g *Group) Get(id string) (*GroupDetailRep, error) {
+	return g.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but carries ctx through to the HTTP request.
+func (g *Group) GetWithContext(ctx context.Context, id string) (*GroupDetailRep, error) {
+	return g.detail(ctx, http.MethodGet, id, nil)
+}
+
+// List retrieves one page of the user groups matching filter, or every group when filter sets no Limit.
+func (g *Group) List(filter *GroupFilter) (*GroupListRep, error) {
+	return g.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List but carries ctx through to the HTTP request.
+func (g *Group) ListWithContext(ctx context.Context, filter *GroupFilter) (*GroupListRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), groupListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.List[GroupDetailRep](ctx, g.API, endpoint, v)
+}
+
+// ListAll fetches every page of the user groups matching filter and returns all of them.
+func (g *Group) ListAll(filter *GroupFilter) ([]GroupDetailRep, error) {
+	return g.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (g *Group) ListAllWithContext(ctx context.Context, filter *GroupFilter) ([]GroupDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), groupListAPI)
+
+	// set query params
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	return apiauth.ListAll[GroupDetailRep](ctx, g.API, endpoint, v)
+}
+
+// Pages returns a pager over the user groups matching filter.
+func (g *Group) Pages(filter *GroupFilter) *apiauth.Pager[GroupDetailRep] {
+	return apiauth.NewQueryPager[GroupDetailRep](g.API, utils.CombineURL(g.API.GetEndpoint(), groupListAPI), filter)
+}
+
+// Iter returns an iterator over every user group matching filter.
+func (g *Group) Iter(filter *GroupFilter) *apiauth.Iterator[GroupDetailRep] {
+	return apiauth.NewQueryIterator[GroupDetailRep](g.API, utils.CombineURL(g.API.GetEndpoint(), groupListAPI), filter)
+}
+
+// Create creates a user group from data and returns the created group.
+func (g *Group) Create(data *GroupReq) (*GroupDetailRep, error) {
+	return g.CreateWithContext(context.Background(), data)
+}
+
+// CreateWithContext is like Create but carries ctx through to the HTTP request.
+func (g *Group) CreateWithContext(ctx context.Context, data *GroupReq) (*GroupDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), groupListAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, g.API, http.MethodPost, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	rep := &GroupDetailRep{}
+	err = g.API.DoRequest(req, rep)
+	return rep, err
+}
+
+// Update replaces the user group identified by id with data and returns the updated group.
+func (g *Group) Update(id string, data *GroupReq) (*GroupDetailRep, error) {
+	return g.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is like Update but carries ctx through to the HTTP request.
+func (g *Group) UpdateWithContext(ctx context.Context, id string, data *GroupReq) (*GroupDetailRep, error) {
+	return g.detail(ctx, http.MethodPut, id, data)
+}
+
+// Delete deletes the user group identified by id, its members are kept.
+func (g *Group) Delete(id string) error {
+	return g.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete but carries ctx through to the HTTP request.
+func (g *Group) DeleteWithContext(ctx context.Context, id string) error {
+	_, err := g.detail(ctx, http.MethodDelete, id, nil)
+	return err
+}
+
+// detail sends data with method to the detail endpoint of the group identified by id.
+// The response is decoded into a GroupDetailRep, except for DELETE which has no content.
+func (g *Group) detail(ctx context.Context, method, id string, data interface{}) (*GroupDetailRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("group id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), fmt.Sprintf(groupGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, g.API, method, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	if method == http.MethodDelete {
+		return nil, g.API.DoRequest(req, nil)
+	}
+	rep := &GroupDetailRep{}
+	err = g.API.DoRequest(req, rep)
+	return rep, err
+}
+
+// AddUsers adds the users identified by userIDs to the group identified by id in a single request.
+func (g *Group) AddUsers(id string, userIDs []string) error {
+	return g.AddUsersWithContext(context.Background(), id, userIDs)
+}
+
+// AddUsersWithContext is like AddUsers but carries ctx through to the HTTP request.
+func (g *Group) AddUsersWithContext(ctx context.Context, id string, userIDs []string) error {
+	// check params
+	if id == "" {
+		return fmt.Errorf("group id can not empty")
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), groupRelationsAPI)
+
+	// make request
+	relations := make([]groupRelation, 0, len(userIDs))
+	for _, userID := range userIDs {
+		relations = append(relations, groupRelation{User: userID, UserGroup: id})
+	}
+	req, err := apiauth.MakeRequestWithContext(ctx, g.API, http.MethodPost, endpoint, relations)
+	if err != nil {
+		return err
+	}
+
+	// do request
+	return g.API.DoRequest(req, nil)
+}
+
+// RemoveUsers removes the users identified by userIDs from the group identified by id.
+// The relations endpoint deletes one membership per request, so a request is sent for each user.
+func (g *Group) RemoveUsers(id string, userIDs []string) error {
+	return g.RemoveUsersWithContext(context.Background(), id, userIDs)
+}
+
+// RemoveUsersWithContext is like RemoveUsers but carries ctx through to the HTTP requests.
+func (g *Group) RemoveUsersWithContext(ctx context.Context, id string, userIDs []string) error {
+	// check id
+	if id == "" {
+		return fmt.Errorf("group id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(g.API.GetEndpoint(), groupRelationsAPI)
+
+	for _, userID := range userIDs {
+		// make request
+		req, err := apiauth.MakeRequestWithContext(ctx, g.API, http.MethodDelete, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		req = g.API.SetQuery(req, url.Values{"usergroup": []string{id}, "user": []string{userID}})
+
+		// do request
+		if err = g.API.DoRequest(req, nil); err != nil {
+			return fmt.Errorf("remove user %s from group error: %w", userID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package users
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGroupRequests(t *testing.T) {
+	none := []string{}
+	tests := []struct {
+		name    string
+		call    func(g *Group) error
+		want    []recorded
+		wantErr bool
+	}{
+		{
+			name: "update keeps members when nil",
+			call: func(g *Group) error {
+				_, err := g.Update("g1", &GroupReq{Name: "ops"})
+				return err
+			},
+			want: []recorded{{Method: http.MethodPut, URI: "/users/groups/g1/", Body: `{"name":"ops"}`}},
+		},
+		{
+			name: "update removes every member",
+			call: func(g *Group) error {
+				_, err := g.Update("g1", &GroupReq{Name: "ops", Users: &none})
+				return err
+			},
+			want: []recorded{{Method: http.MethodPut, URI: "/users/groups/g1/", Body: `{"name":"ops","users":[]}`}},
+		},
+		{
+			name: "add users",
+			call: func(g *Group) error { return g.AddUsers("g1", []string{"u1", "u2"}) },
+			want: []recorded{{Method: http.MethodPost, URI: "/users/users-groups-relations/", Body: `[{"user":"u1","usergroup":"g1"},{"user":"u2","usergroup":"g1"}]`}},
+		},
+		{
+			name: "add no user",
+			call: func(g *Group) error { return g.AddUsers("g1", nil) },
+		},
+		{
+			name: "remove users",
+			call: func(g *Group) error { return g.RemoveUsers("g1", []string{"u1", "u2"}) },
+			want: []recorded{
+				{Method: http.MethodDelete, URI: "/users/users-groups-relations/?user=u1&usergroup=g1"},
+				{Method: http.MethodDelete, URI: "/users/users-groups-relations/?user=u2&usergroup=g1"},
+			},
+		},
+		{
+			name:    "empty id",
+			call:    func(g *Group) error { return g.AddUsers("", []string{"u1"}) },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []recorded
+			api := newTestServer(t, `{}`, &requests)
+			err := tt.call(&Group{API: api})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			checkRequests(t, requests, tt.want)
+		})
+	}
+}
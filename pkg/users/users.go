@@ -1,6 +1,7 @@
 package users
 
 import (
+	"context"
 	"fmt"
 	"github.com/MScuti/gojms/pkg/apiauth"
 	"github.com/MScuti/gojms/pkg/utils"
@@ -76,14 +77,9 @@ type UserDetailRep struct {
 	DatePasswordLastUpdated string `json:"date_password_last_updated"`
 }
 
-// UserListRep is a type that represents a list of AccountDetailRep objects.
-// It's essentially a slice of AccountDetailRep struct instances representing multiple accounts details.
-type UserListRep struct {
-	Count    int             `json:"count"`
-	Next     interface{}     `json:"next"`
-	Previous interface{}     `json:"previous"`
-	Results  []UserDetailRep `json:"results"`
-}
+// UserListRep is the paginated response of the users list endpoint.
+// Results holds the UserDetailRep of the current page.
+type UserListRep = apiauth.ListRep[UserDetailRep]
 
 type UserAssets struct {
 	Id       string      `json:"id"`
@@ -129,6 +125,11 @@ type UserAssets struct {
 // If the retrieval and unmarshalling are successful, it returns the AccountDetailRep
 // object along with a nil error. Otherwise, it returns nil and the associated error.
 func (u *User) Get(id string) (*UserDetailRep, error) {
+	return u.GetWithContext(context.Background(), id)
+}
+
+// GetWithContext is like Get but carries ctx through to the HTTP request.
+func (u *User) GetWithContext(ctx context.Context, id string) (*UserDetailRep, error) {
 	// check id
 	if id == "" {
 		return nil, fmt.Errorf("session id can not empty")
@@ -139,7 +140,7 @@ func (u *User) Get(id string) (*UserDetailRep, error) {
 	endpoint = fmt.Sprintf(endpoint, id)
 
 	// make request
-	req, err := u.API.MakeRequest(http.MethodGet, endpoint, nil)
+	req, err := apiauth.MakeRequestWithContext(ctx, u.API, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -158,48 +159,67 @@ func (u *User) Get(id string) (*UserDetailRep, error) {
 // If the operations are successful, it returns a pointer to the AccountListRep object and a nil error.
 // If there's an error during these operations, it returns nil and the error.
 func (u *User) List(filter *UserFilter) (*UserListRep, error) {
+	return u.ListWithContext(context.Background(), filter)
+}
+
+// ListWithContext is like List but carries ctx through to the HTTP request.
+func (u *User) ListWithContext(ctx context.Context, filter *UserFilter) (*UserListRep, error) {
 	// combine api endpoint
 	endpoint := utils.CombineURL(u.API.GetEndpoint(), userListAPI)
 
-	// make request
-	req, err := u.API.MakeRequest(http.MethodGet, endpoint, nil)
+	// set query params
+	v, err := query.Values(filter)
 	if err != nil {
 		return nil, err
 	}
 
+	// do request
+	return apiauth.List[UserDetailRep](ctx, u.API, endpoint, v)
+}
+
+// ListAll fetches every page of the users matching filter and returns all of them.
+// The Limit of filter is used as page size, apiauth.DefaultPageSize when it is not set.
+func (u *User) ListAll(filter *UserFilter) ([]UserDetailRep, error) {
+	return u.ListAllWithContext(context.Background(), filter)
+}
+
+// ListAllWithContext is like ListAll but issues every page request with ctx.
+func (u *User) ListAllWithContext(ctx context.Context, filter *UserFilter) ([]UserDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(u.API.GetEndpoint(), userListAPI)
+
 	// set query params
-	if filter != nil {
-		v, err := query.Values(filter)
-		if err != nil {
-			return nil, err
-		}
-		req = u.API.SetQuery(req, v)
+	v, err := query.Values(filter)
+	if err != nil {
+		return nil, err
 	}
 
 	// do request
-	if req.URL.Query().Get("limit") != "" {
-		data := &UserListRep{}
-		err = u.API.DoRequest(req, data)
-		return data, err
-	} else {
-		data := make([]UserDetailRep, 0)
-		err = u.API.DoRequest(req, &data)
-		if err != nil {
-			return nil, err
-		}
-		return &UserListRep{
-			Count:   len(data),
-			Results: data,
-		}, nil
-	}
+	return apiauth.ListAll[UserDetailRep](ctx, u.API, endpoint, v)
+}
+
+// Pages returns a pager over the users matching filter, fetching one page per call to Next.
+// The Limit and Offset of filter set the page size and the starting offset.
+func (u *User) Pages(filter *UserFilter) *apiauth.Pager[UserDetailRep] {
+	return apiauth.NewQueryPager[UserDetailRep](u.API, utils.CombineURL(u.API.GetEndpoint(), userListAPI), filter)
+}
+
+// Iter returns an iterator over every user matching filter, fetching the pages on demand.
+func (u *User) Iter(filter *UserFilter) *apiauth.Iterator[UserDetailRep] {
+	return apiauth.NewQueryIterator[UserDetailRep](u.API, utils.CombineURL(u.API.GetEndpoint(), userListAPI), filter)
 }
 
 func (u *User) Assets(id string) (*[]UserAssets, error) {
+	return u.AssetsWithContext(context.Background(), id)
+}
+
+// AssetsWithContext is like Assets but carries ctx through to the HTTP request.
+func (u *User) AssetsWithContext(ctx context.Context, id string) (*[]UserAssets, error) {
 	// combine api endpoint
 	endpoint := utils.CombineURL(u.API.GetEndpoint(), fmt.Sprintf(userAssetsAPI, id))
 
 	// make request
-	req, err := u.API.MakeRequest(http.MethodGet, endpoint, nil)
+	req, err := apiauth.MakeRequestWithContext(ctx, u.API, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -209,3 +229,251 @@ func (u *User) Assets(id string) (*[]UserAssets, error) {
 	err = u.API.DoRequest(req, &data)
 	return &data, err
 }
+
+// UserReq is the request body used to create a user or to fully update it with PUT.
+// Groups, SystemRoles and OrgRoles hold ids. PasswordStrategy is either 'email', to send the user
+// a mail to set the password, or 'custom', to use Password. NeedUpdatePassword and IsActive are left to
+// the server default when nil, a new user is active.
+type UserReq struct {
+	Name               string   `json:"name"`
+	Username           string   `json:"username"`
+	Email              string   `json:"email"`
+	Phone              string   `json:"phone,omitempty"`
+	Wechat             string   `json:"wechat,omitempty"`
+	Comment            string   `json:"comment,omitempty"`
+	Source             string   `json:"source,omitempty"`
+	Groups             []string `json:"groups,omitempty"`
+	SystemRoles        []string `json:"system_roles,omitempty"`
+	OrgRoles           []string `json:"org_roles,omitempty"`
+	MfaLevel           int      `json:"mfa_level"`
+	PasswordStrategy   string   `json:"password_strategy,omitempty"`
+	Password           string   `json:"password,omitempty"`
+	NeedUpdatePassword *bool    `json:"need_update_password,omitempty"`
+	IsActive           *bool    `json:"is_active,omitempty"`
+	DateExpired        string   `json:"date_expired,omitempty"`
+}
+
+// UserPatchReq is the request body used to partially update a user with PATCH.
+// Only the non nil fields are sent.
+type UserPatchReq struct {
+	Name               *string   `json:"name,omitempty"`
+	Username           *string   `json:"username,omitempty"`
+	Email              *string   `json:"email,omitempty"`
+	Phone              *string   `json:"phone,omitempty"`
+	Wechat             *string   `json:"wechat,omitempty"`
+	Comment            *string   `json:"comment,omitempty"`
+	Groups             *[]string `json:"groups,omitempty"`
+	SystemRoles        *[]string `json:"system_roles,omitempty"`
+	OrgRoles           *[]string `json:"org_roles,omitempty"`
+	MfaLevel           *int      `json:"mfa_level,omitempty"`
+	Password           *string   `json:"password,omitempty"`
+	NeedUpdatePassword *bool     `json:"need_update_password,omitempty"`
+	IsActive           *bool     `json:"is_active,omitempty"`
+	DateExpired        *string   `json:"date_expired,omitempty"`
+}
+
+// UserBulkReq is an item of a bulk PUT update, the user to update is identified by Id.
+type UserBulkReq struct {
+	Id string `json:"id"`
+	UserReq
+}
+
+// UserBulkPatchReq is an item of a bulk PATCH update, the user to update is identified by Id.
+type UserBulkPatchReq struct {
+	Id string `json:"id"`
+	UserPatchReq
+}
+
+// Create creates a user from data and returns the created user.
+func (u *User) Create(data *UserReq) (*UserDetailRep, error) {
+	return u.CreateWithContext(context.Background(), data)
+}
+
+// CreateWithContext is like Create but carries ctx through to the HTTP request.
+func (u *User) CreateWithContext(ctx context.Context, data *UserReq) (*UserDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(u.API.GetEndpoint(), userListAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, u.API, http.MethodPost, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	rep := &UserDetailRep{}
+	err = u.API.DoRequest(req, rep)
+	return rep, err
+}
+
+// Update replaces the user identified by id with data (PUT) and returns the updated user.
+func (u *User) Update(id string, data *UserReq) (*UserDetailRep, error) {
+	return u.UpdateWithContext(context.Background(), id, data)
+}
+
+// UpdateWithContext is like Update but carries ctx through to the HTTP request.
+func (u *User) UpdateWithContext(ctx context.Context, id string, data *UserReq) (*UserDetailRep, error) {
+	return u.update(ctx, http.MethodPut, id, data)
+}
+
+// PartialUpdate updates the non nil fields of data on the user identified by id (PATCH)
+// and returns the updated user.
+func (u *User) PartialUpdate(id string, data *UserPatchReq) (*UserDetailRep, error) {
+	return u.PartialUpdateWithContext(context.Background(), id, data)
+}
+
+// PartialUpdateWithContext is like PartialUpdate but carries ctx through to the HTTP request.
+func (u *User) PartialUpdateWithContext(ctx context.Context, id string, data *UserPatchReq) (*UserDetailRep, error) {
+	return u.update(ctx, http.MethodPatch, id, data)
+}
+
+// Deactivate disables the user identified by id, the user can no longer log in.
+func (u *User) Deactivate(id string) (*UserDetailRep, error) {
+	return u.DeactivateWithContext(context.Background(), id)
+}
+
+// DeactivateWithContext is like Deactivate but carries ctx through to the HTTP request.
+func (u *User) DeactivateWithContext(ctx context.Context, id string) (*UserDetailRep, error) {
+	active := false
+	return u.update(ctx, http.MethodPatch, id, &UserPatchReq{IsActive: &active})
+}
+
+// update sends data with method to the detail endpoint of the user identified by id.
+func (u *User) update(ctx context.Context, method, id string, data interface{}) (*UserDetailRep, error) {
+	// check id
+	if id == "" {
+		return nil, fmt.Errorf("user id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(u.API.GetEndpoint(), fmt.Sprintf(userGetAPI, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, u.API, method, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	rep := &UserDetailRep{}
+	err = u.API.DoRequest(req, rep)
+	return rep, err
+}
+
+// Delete deletes the user identified by id.
+func (u *User) Delete(id string) error {
+	return u.DeleteWithContext(context.Background(), id)
+}
+
+// DeleteWithContext is like Delete but carries ctx through to the HTTP request.
+func (u *User) DeleteWithContext(ctx context.Context, id string) error {
+	return u.action(ctx, http.MethodDelete, userGetAPI, id)
+}
+
+// ResetMFA resets the MFA of the user identified by id, the user must bind it again on next login.
+func (u *User) ResetMFA(id string) error {
+	return u.ResetMFAWithContext(context.Background(), id)
+}
+
+// ResetMFAWithContext is like ResetMFA but carries ctx through to the HTTP request.
+func (u *User) ResetMFAWithContext(ctx context.Context, id string) error {
+	return u.action(ctx, http.MethodGet, userMFAResetAPI, id)
+}
+
+// Unblock unblocks the login of the user identified by id after too many failed attempts.
+func (u *User) Unblock(id string) error {
+	return u.UnblockWithContext(context.Background(), id)
+}
+
+// UnblockWithContext is like Unblock but carries ctx through to the HTTP request.
+func (u *User) UnblockWithContext(ctx context.Context, id string) error {
+	return u.action(ctx, http.MethodPatch, userUnblockAPI, id)
+}
+
+// ResetPassword forces the user identified by id to change the password.
+// The server sends the user a mail with a link to set a new password.
+func (u *User) ResetPassword(id string) error {
+	return u.ResetPasswordWithContext(context.Background(), id)
+}
+
+// ResetPasswordWithContext is like ResetPassword but carries ctx through to the HTTP request.
+func (u *User) ResetPasswordWithContext(ctx context.Context, id string) error {
+	return u.action(ctx, http.MethodPatch, userPasswordResetAPI, id)
+}
+
+// action sends a request without body to the api of the user identified by id and discards the response.
+func (u *User) action(ctx context.Context, method, api, id string) error {
+	// check id
+	if id == "" {
+		return fmt.Errorf("user id can not empty")
+	}
+
+	// combine api endpoint
+	endpoint := utils.CombineURL(u.API.GetEndpoint(), fmt.Sprintf(api, id))
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, u.API, method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	// do request
+	return u.API.DoRequest(req, nil)
+}
+
+// BulkCreate creates every user of data in a single request and returns the created users.
+func (u *User) BulkCreate(data []UserReq) ([]UserDetailRep, error) {
+	return u.BulkCreateWithContext(context.Background(), data)
+}
+
+// BulkCreateWithContext is like BulkCreate but carries ctx through to the HTTP request.
+func (u *User) BulkCreateWithContext(ctx context.Context, data []UserReq) ([]UserDetailRep, error) {
+	return u.bulk(ctx, http.MethodPost, data)
+}
+
+// BulkUpdate replaces every user of data, identified by their Id, in a single request.
+func (u *User) BulkUpdate(data []UserBulkReq) ([]UserDetailRep, error) {
+	return u.BulkUpdateWithContext(context.Background(), data)
+}
+
+// BulkUpdateWithContext is like BulkUpdate but carries ctx through to the HTTP request.
+func (u *User) BulkUpdateWithContext(ctx context.Context, data []UserBulkReq) ([]UserDetailRep, error) {
+	return u.bulk(ctx, http.MethodPut, data)
+}
+
+// BulkPartialUpdate updates the non nil fields of every user of data, identified by their Id, in a single request.
+func (u *User) BulkPartialUpdate(data []UserBulkPatchReq) ([]UserDetailRep, error) {
+	return u.BulkPartialUpdateWithContext(context.Background(), data)
+}
+
+// BulkPartialUpdateWithContext is like BulkPartialUpdate but carries ctx through to the HTTP request.
+func (u *User) BulkPartialUpdateWithContext(ctx context.Context, data []UserBulkPatchReq) ([]UserDetailRep, error) {
+	return u.bulk(ctx, http.MethodPatch, data)
+}
+
+// bulk sends data with method to the users list endpoint and decodes the returned users.
+func (u *User) bulk(ctx context.Context, method string, data interface{}) ([]UserDetailRep, error) {
+	// combine api endpoint
+	endpoint := utils.CombineURL(u.API.GetEndpoint(), userListAPI)
+
+	// make request
+	req, err := apiauth.MakeRequestWithContext(ctx, u.API, method, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// do request
+	rep := make([]UserDetailRep, 0)
+	err = u.API.DoRequest(req, &rep)
+	return rep, err
+}
+
+// BulkDelete deletes every user identified by ids in a single request.
+func (u *User) BulkDelete(ids []string) error {
+	return u.BulkDeleteWithContext(context.Background(), ids)
+}
+
+// BulkDeleteWithContext is like BulkDelete but carries ctx through to the HTTP request.
+func (u *User) BulkDeleteWithContext(ctx context.Context, ids []string) error {
+	return apiauth.DeleteResources(ctx, u.API, utils.CombineURL(u.API.GetEndpoint(), userListAPI), ids)
+}
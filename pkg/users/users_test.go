@@ -0,0 +1,150 @@
+package users
+
+import (
+	"encoding/json"
+	"github.com/MScuti/gojms/pkg/apiauth"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// recorded is a request received by the test server.
+type recorded struct {
+	Method string
+	URI    string
+	Body   string
+}
+
+// newTestServer returns a server recording every request into requests and answering with reply.
+func newTestServer(t *testing.T, reply string, requests *[]recorded) apiauth.JmsAPI {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, recorded{Method: r.Method, URI: r.URL.RequestURI(), Body: string(body)})
+		w.Write([]byte(reply))
+	}))
+	t.Cleanup(srv.Close)
+	return &apiauth.JmsAPIConfig{Endpoints: srv.URL, Token: "token"}
+}
+
+// checkRequests compares the recorded requests with want, the bodies are compared as JSON values.
+func checkRequests(t *testing.T, got, want []recorded) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("sent %d requests %v, want %d %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if got[i].Method != want[i].Method || got[i].URI != want[i].URI {
+			t.Errorf("request %d = %s %s, want %s %s", i, got[i].Method, got[i].URI, want[i].Method, want[i].URI)
+		}
+		if !jsonEqual(got[i].Body, want[i].Body) {
+			t.Errorf("request %d body = %s, want %s", i, got[i].Body, want[i].Body)
+		}
+	}
+}
+
+// jsonEqual reports whether a and b hold the same JSON value, or are both empty.
+func jsonEqual(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestUserRequests(t *testing.T) {
+	active := true
+	tests := []struct {
+		name    string
+		reply   string
+		call    func(u *User) error
+		want    []recorded
+		wantErr bool
+	}{
+		{
+			name: "create leaves is_active to the server",
+			call: func(u *User) error {
+				_, err := u.Create(&UserReq{Name: "Demo", Username: "demo", Email: "demo@example.com"})
+				return err
+			},
+			want: []recorded{{Method: http.MethodPost, URI: "/users/users/", Body: `{"name":"Demo","username":"demo","email":"demo@example.com","mfa_level":0}`}},
+		},
+		{
+			name: "update",
+			call: func(u *User) error {
+				_, err := u.Update("u1", &UserReq{Name: "Demo", Username: "demo", Email: "demo@example.com", IsActive: &active})
+				return err
+			},
+			want: []recorded{{Method: http.MethodPut, URI: "/users/users/u1/", Body: `{"name":"Demo","username":"demo","email":"demo@example.com","mfa_level":0,"is_active":true}`}},
+		},
+		{
+			name: "deactivate",
+			call: func(u *User) error {
+				_, err := u.Deactivate("u1")
+				return err
+			},
+			want: []recorded{{Method: http.MethodPatch, URI: "/users/users/u1/", Body: `{"is_active":false}`}},
+		},
+		{
+			name: "delete",
+			call: func(u *User) error { return u.Delete("u1") },
+			want: []recorded{{Method: http.MethodDelete, URI: "/users/users/u1/"}},
+		},
+		{
+			name: "reset mfa",
+			call: func(u *User) error { return u.ResetMFA("u1") },
+			want: []recorded{{Method: http.MethodGet, URI: "/users/users/u1/mfa/reset/"}},
+		},
+		{
+			name: "unblock",
+			call: func(u *User) error { return u.Unblock("u1") },
+			want: []recorded{{Method: http.MethodPatch, URI: "/users/users/u1/unblock/"}},
+		},
+		{
+			name:  "bulk create",
+			reply: `[{"id":"u1"},{"id":"u2"}]`,
+			call: func(u *User) error {
+				users, err := u.BulkCreate([]UserReq{{Name: "A", Username: "a"}, {Name: "B", Username: "b"}})
+				if err == nil && len(users) != 2 {
+					t.Errorf("BulkCreate() returned %d users, want 2", len(users))
+				}
+				return err
+			},
+			want: []recorded{{Method: http.MethodPost, URI: "/users/users/", Body: `[{"name":"A","username":"a","email":"","mfa_level":0},{"name":"B","username":"b","email":"","mfa_level":0}]`}},
+		},
+		{
+			name:  "bulk delete",
+			reply: `{"spm":"key"}`,
+			call:  func(u *User) error { return u.BulkDelete([]string{"u1", "u2"}) },
+			want: []recorded{
+				{Method: http.MethodPost, URI: "/common/resources/cache/", Body: `{"resources":["u1","u2"]}`},
+				{Method: http.MethodDelete, URI: "/users/users/?spm=key"},
+			},
+		},
+		{
+			name:    "empty id",
+			call:    func(u *User) error { return u.Delete("") },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := tt.reply
+			if reply == "" {
+				reply = `{}`
+			}
+			var requests []recorded
+			api := newTestServer(t, reply, &requests)
+			err := tt.call(&User{API: api})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			checkRequests(t, requests, tt.want)
+		})
+	}
+}
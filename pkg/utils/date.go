@@ -0,0 +1,19 @@
+package utils
+
+import "time"
+
+// dateLayouts are the layouts of the dates returned by the server, RFC 3339 or the JumpServer
+// DATETIME_FORMAT setting.
+var dateLayouts = []string{time.RFC3339Nano, "2006/01/02 15:04:05 -0700"}
+
+// ParseDate parses a date returned by the server, either RFC 3339 or JumpServer's '2006/01/02 15:04:05 -0700'.
+func ParseDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range dateLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
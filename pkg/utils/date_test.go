@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2024-01-02T03:04:05Z", want: want},
+		{value: "2024-01-02T11:04:05.000+08:00", want: want},
+		{value: "2024/01/02 11:04:05 +0800", want: want},
+		{value: "2024-01-02 03:04:05", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
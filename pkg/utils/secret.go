@@ -0,0 +1,211 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// redacted replaces the secret content wherever a Secret is formatted or logged.
+const redacted = "[REDACTED]"
+
+// Secret holds a secret sent to or received from JumpServer, an account password, an SSH private key,
+// a token or a storage secret key.
+// Its content is only returned by Bytes and by MarshalJSON, which sends it in the request bodies,
+// formatting it with any fmt verb or logging it with slog yields "[REDACTED]". Zero overwrites the content
+// once it is no longer needed, and it is overwritten anyway when the Secret is garbage collected.
+// Copies of a Secret share its content.
+type Secret struct {
+	buf *secretBuf
+}
+
+// secretBuf is allocated apart from Secret so that it can carry the finalizer zeroing it.
+type secretBuf struct {
+	b []byte
+}
+
+// newSecret returns a Secret owning b.
+func newSecret(b []byte) Secret {
+	buf := &secretBuf{b: b}
+	runtime.SetFinalizer(buf, func(buf *secretBuf) { zero(buf.b) })
+	return Secret{buf: buf}
+}
+
+// NewSecret returns a Secret owning b, to be set in a request body.
+// b must not be used by the caller afterwards, it is zeroed together with the Secret.
+func NewSecret(b []byte) *Secret {
+	s := newSecret(b)
+	return &s
+}
+
+// Bytes returns the content of the secret, nil once it has been zeroed.
+// The returned slice is the secret storage itself, it must not be retained after Zero.
+func (s Secret) Bytes() []byte {
+	if s.buf == nil {
+		return nil
+	}
+	return s.buf.b
+}
+
+// Len returns the length of the secret content.
+func (s Secret) Len() int {
+	return len(s.Bytes())
+}
+
+// Zero overwrites the content of the secret with zeros and releases it.
+func (s *Secret) Zero() {
+	if s.buf == nil {
+		return
+	}
+	zero(s.buf.b)
+	s.buf.b = nil
+}
+
+// String returns "[REDACTED]".
+func (s Secret) String() string {
+	return redacted
+}
+
+// GoString returns "[REDACTED]", so that %#v does not print the content either.
+func (s Secret) GoString() string {
+	return redacted
+}
+
+// Format writes "[REDACTED]" whatever the verb.
+func (s Secret) Format(f fmt.State, verb rune) {
+	io.WriteString(f, redacted)
+}
+
+// LogValue returns "[REDACTED]" to slog.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// MarshalJSON returns the content of the secret as a JSON string, without going through an immutable
+// Go string. A zeroed Secret is marshaled as an empty string.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return quote(s.Bytes()), nil
+}
+
+// UnmarshalJSON decodes a JSON string into the secret without going through an immutable Go string,
+// so that every copy of the content can be zeroed.
+func (s *Secret) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = Secret{}
+		return nil
+	}
+	b, err := unquote(data)
+	if err != nil {
+		return err
+	}
+	*s = newSecret(b)
+	return nil
+}
+
+// zero overwrites b with zeros.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// unquote decodes the JSON string literal data into a new byte slice. The slice is allocated once
+// with the length of data, which is never shorter than the decoded content, so no partial copy is left behind.
+func unquote(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return nil, errors.New("secret is not a json string")
+	}
+	data = data[1 : len(data)-1]
+	b := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c != '\\' {
+			b = append(b, c)
+			continue
+		}
+		i++
+		if i >= len(data) {
+			zero(b)
+			return nil, errors.New("invalid escape in secret")
+		}
+		switch data[i] {
+		case '"', '\\', '/':
+			b = append(b, data[i])
+		case 'b':
+			b = append(b, '\b')
+		case 'f':
+			b = append(b, '\f')
+		case 'n':
+			b = append(b, '\n')
+		case 'r':
+			b = append(b, '\r')
+		case 't':
+			b = append(b, '\t')
+		case 'u':
+			r, ok := hex4(data[i+1:])
+			if !ok {
+				zero(b)
+				return nil, errors.New("invalid unicode escape in secret")
+			}
+			i += 4
+			if utf16.IsSurrogate(r) {
+				r2, ok := rune(0), false
+				if i+2 < len(data) && data[i+1] == '\\' && data[i+2] == 'u' {
+					r2, ok = hex4(data[i+3:])
+				}
+				if r = utf16.DecodeRune(r, r2); ok && r != utf8.RuneError {
+					i += 6
+				}
+			}
+			b = utf8.AppendRune(b, r)
+		default:
+			zero(b)
+			return nil, errors.New("invalid escape in secret")
+		}
+	}
+	return b, nil
+}
+
+// quote encodes b as a JSON string literal into a new byte slice.
+func quote(b []byte) []byte {
+	const hex = "0123456789abcdef"
+	q := make([]byte, 0, len(b)+2)
+	q = append(q, '"')
+	for _, c := range b {
+		switch {
+		case c == '"' || c == '\\':
+			q = append(q, '\\', c)
+		case c < 0x20:
+			q = append(q, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+		default:
+			q = append(q, c)
+		}
+	}
+	return append(q, '"')
+}
+
+// hex4 decodes the four hexadecimal digits at the start of data.
+func hex4(data []byte) (rune, bool) {
+	if len(data) < 4 {
+		return 0, false
+	}
+	var r rune
+	for _, c := range data[:4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			return 0, false
+		}
+		r = r<<4 | rune(c)
+	}
+	return r, true
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		json    string
+	}{
+		{name: "password", content: "P@ssw0rd", json: `"P@ssw0rd"`},
+		{name: "escaped", content: "a\"b\\c\n\x01", json: `"a\"b\\c\u000a\u0001"`},
+		{name: "unicode", content: "mot de passe é", json: `"mot de passe é"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSecret([]byte(tt.content))
+			for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x"} {
+				for _, v := range []interface{}{s, *s} {
+					if out := fmt.Sprintf(verb, v); out != redacted {
+						t.Errorf("Sprintf(%q) = %s, want %s", verb, out, redacted)
+					}
+				}
+			}
+
+			var logs bytes.Buffer
+			slog.New(slog.NewTextHandler(&logs, nil)).Info("secret", "value", s)
+			if strings.Contains(logs.String(), tt.content) || !strings.Contains(logs.String(), "value="+redacted) {
+				t.Errorf("slog = %s, want %s", logs.String(), redacted)
+			}
+
+			body, err := json.Marshal(s)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(body) != tt.json {
+				t.Errorf("Marshal() = %s, want %s", body, tt.json)
+			}
+
+			var got Secret
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if string(got.Bytes()) != tt.content {
+				t.Errorf("Unmarshal() = %q, want %q", got.Bytes(), tt.content)
+			}
+			got.Zero()
+			if got.Len() != 0 {
+				t.Errorf("Zero() left %d bytes", got.Len())
+			}
+		})
+	}
+}
+
+func TestSecretUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    string
+		wantErr bool
+	}{
+		{name: "surrogate pair", json: `"🔑"`, want: "\U0001F511"},
+		{name: "escaped slash", json: `"a\/b"`, want: "a/b"},
+		{name: "null", json: `null`, want: ""},
+		{name: "not a string", json: `123`, wantErr: true},
+		{name: "bad escape", json: `"a\qb"`, wantErr: true},
+		{name: "bad unicode escape", json: `"\u12"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Secret
+			err := s.UnmarshalJSON([]byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(s.Bytes()) != tt.want {
+				t.Errorf("UnmarshalJSON() = %q, want %q", s.Bytes(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSecretZero(t *testing.T) {
+	b := []byte("P@ssw0rd")
+	s := NewSecret(b)
+	copied := *s
+	s.Zero()
+	if !bytes.Equal(b, make([]byte, len(b))) {
+		t.Errorf("Zero() left %q in the owned slice", b)
+	}
+	if copied.Bytes() != nil {
+		t.Errorf("Zero() left %q in a copy", copied.Bytes())
+	}
+	if body, _ := json.Marshal(copied); string(body) != `""` {
+		t.Errorf("Marshal() of a zeroed secret = %s", body)
+	}
+
+	var empty Secret
+	empty.Zero()
+	if empty.Len() != 0 || empty.String() != redacted {
+		t.Errorf("zero value secret = %q", empty.Bytes())
+	}
+}